@@ -0,0 +1,647 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/build"
+	"go/format"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/antonmashko/envconf/option"
+)
+
+const (
+	tagFlag        = "flag"
+	tagEnv         = "env"
+	tagDefault     = "default"
+	tagRequired    = "required"
+	tagDescription = "description"
+	tagSecret      = "secret"
+	tagSources     = "sources"
+	tagPriority    = "priority"
+	tagEnvconf     = "envconf"
+	tagIgnored     = "-"
+	valDefault     = "*"
+
+	generatedSuffix = "_envconf.go"
+)
+
+// generate returns source of the parse functions for the types of the package in dir
+func generate(dir, output string, typeNames []string) ([]byte, error) {
+	pkg, err := loadPackage(dir, output)
+	if err != nil {
+		return nil, err
+	}
+	g := &generator{
+		pkg:     pkg,
+		imports: map[string]string{"reflect": "reflect"},
+	}
+	var body bytes.Buffer
+	for _, name := range typeNames {
+		name = strings.TrimSpace(name)
+		obj, ok := pkg.Scope().Lookup(name).(*types.TypeName)
+		if !ok {
+			return nil, fmt.Errorf("type %s is not found in %s", name, dir)
+		}
+		st, ok := obj.Type().Underlying().(*types.Struct)
+		if !ok {
+			return nil, fmt.Errorf("type %s is not a struct", name)
+		}
+		g.fields, g.flags = nil, make(map[string]string)
+		if err = g.walk(st, nil, nil, nil, false); err != nil {
+			return nil, fmt.Errorf("%s.%w", name, err)
+		}
+		g.writeType(&body, name)
+	}
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "// Code generated by envconfgen. DO NOT EDIT.\n\n")
+	fmt.Fprintf(&buf, "package %s\n\n", pkg.Name())
+	g.imports["github.com/antonmashko/envconf"] = "envconf"
+	g.imports["github.com/antonmashko/envconf/option"] = "option"
+	paths := make([]string, 0, len(g.imports))
+	for path := range g.imports {
+		paths = append(paths, path)
+	}
+	sort.Slice(paths, func(i, j int) bool {
+		if isStd(paths[i]) != isStd(paths[j]) {
+			return isStd(paths[i])
+		}
+		return paths[i] < paths[j]
+	})
+	buf.WriteString("import (\n")
+	for i, path := range paths {
+		if i > 0 && isStd(paths[i-1]) && !isStd(path) {
+			buf.WriteString("\n")
+		}
+		fmt.Fprintf(&buf, "\t%q\n", path)
+	}
+	buf.WriteString(")\n")
+	buf.Write(body.Bytes())
+	return format.Source(buf.Bytes())
+}
+
+func isStd(path string) bool {
+	return !strings.Contains(strings.Split(path, "/")[0], ".")
+}
+
+// loadPackage type-checks non-test files of the package in dir. Previously generated files are skipped.
+// Type errors are ignored, so package that calls not yet generated functions can be loaded
+func loadPackage(dir, output string) (*types.Package, error) {
+	bp, err := build.ImportDir(dir, 0)
+	if err != nil {
+		return nil, err
+	}
+	fset := token.NewFileSet()
+	var files []*ast.File
+	for _, name := range bp.GoFiles {
+		if name == output || strings.HasSuffix(name, generatedSuffix) {
+			continue
+		}
+		f, err := parser.ParseFile(fset, filepath.Join(dir, name), nil, 0)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, f)
+	}
+	conf := types.Config{
+		Importer: importer.ForCompiler(fset, "source", nil),
+		Error:    func(error) {},
+	}
+	pkg, _ := conf.Check(bp.ImportPath, fset, files, nil)
+	if pkg == nil {
+		return nil, errors.New("unable to load package " + dir)
+	}
+	return pkg, nil
+}
+
+type fieldKind int
+
+const (
+	kindBasic fieldKind = iota
+	kindDuration
+	kindCustom
+)
+
+// field is a leaf configuration field with values computed from the struct tags
+type field struct {
+	name     string
+	fullName string
+	// path is a selector of the field from the root struct
+	path []string
+	// parents are names of the parent structs used for generating configuration names
+	parents []string
+	t       types.Type
+	// vt is the type of the value converted from a string: t, type of the pointer value or type of the slice elements
+	vt    types.Type
+	ptr   bool
+	slice bool
+	// bytes is set for slices of bytes that are set from a string as is
+	bytes       bool
+	kind        fieldKind
+	method      string
+	flag, env   string
+	def         string
+	hasDefault  bool
+	required    bool
+	secret      bool
+	description string
+	// sources are allowed by the sources tag, nil if all sources are allowed
+	sources []option.ConfigSource
+	// priority is priority order of the field or its closest parent, nil if order of options is used
+	priority []option.ConfigSource
+}
+
+type generator struct {
+	pkg     *types.Package
+	imports map[string]string
+	fields  []*field
+	flags   map[string]string
+}
+
+// walk collects fields of the struct in the same order as envconf initializes them
+func (g *generator) walk(st *types.Struct, path, parents []string, priority []option.ConfigSource, secret bool) error {
+	for i := 0; i < st.NumFields(); i++ {
+		v := st.Field(i)
+		if !v.Exported() {
+			// unexported fields are skipped by envconf
+			continue
+		}
+		tag := reflect.StructTag(st.Tag(i))
+		fpriority := priority
+		if str, ok := tag.Lookup(tagPriority); ok {
+			p, err := option.ParseConfigSources(str)
+			if err != nil {
+				return fmt.Errorf("%s: invalid priority tag %q: %w", v.Name(), str, err)
+			}
+			fpriority = uniqueSources(p)
+		}
+		fsecret := secret
+		if str, ok := tag.Lookup(tagSecret); ok {
+			b, err := strconv.ParseBool(str)
+			if err != nil {
+				return fmt.Errorf("%s: invalid secret tag %q: %w", v.Name(), str, err)
+			}
+			fsecret = fsecret || b
+		}
+		fpath := append(path[:len(path):len(path)], v.Name())
+		t := v.Type()
+		f := &field{name: v.Name(), path: fpath, parents: parents, t: t, vt: t, secret: fsecret, priority: fpriority}
+		// the same order as envconf uses: pointers are checked first,
+		// then custom unmarshaling has priority over the kind of the type
+		switch ut := t.Underlying().(type) {
+		case *types.Pointer:
+			f.ptr, f.vt = true, ut.Elem()
+		case *types.Slice:
+			if unmarshalMethod(t) == "" {
+				f.slice, f.vt = true, ut.Elem()
+				b, ok := ut.Elem().Underlying().(*types.Basic)
+				f.bytes = ok && b.Kind() == types.Uint8
+			}
+		}
+		var ok bool
+		if f.kind, f.method, ok = valueKind(f.vt); !ok && !f.bytes {
+			if f.ptr || f.slice {
+				return fmt.Errorf("%s: type %s isn't supported by generated code", v.Name(), t)
+			}
+			switch ut := t.Underlying().(type) {
+			case *types.Chan, *types.Signature, *types.Basic:
+				// unsupported types are skipped by envconf
+				continue
+			case *types.Struct:
+				sname := v.Name()
+				if name := tag.Get(tagEnvconf); name != "" {
+					sname = name
+				}
+				if err := g.walk(ut, fpath, append(parents[:len(parents):len(parents)], sname), fpriority, fsecret); err != nil {
+					return fmt.Errorf("%s.%w", v.Name(), err)
+				}
+				continue
+			default:
+				return fmt.Errorf("%s: type %s isn't supported by generated code", v.Name(), t)
+			}
+		}
+		if err := g.addField(f, tag); err != nil {
+			return fmt.Errorf("%s: %w", v.Name(), err)
+		}
+	}
+	return nil
+}
+
+func (g *generator) addField(f *field, tag reflect.StructTag) error {
+	f.fullName = strings.Join(append(f.parents[:len(f.parents):len(f.parents)], f.name), ".")
+	f.flag = configName(f, tag, tagFlag, "-", strings.ToLower)
+	f.env = configName(f, tag, tagEnv, "_", strings.ToUpper)
+	f.def, f.hasDefault = tag.Lookup(tagDefault)
+	if req, ok := tag.Lookup(tagRequired); ok {
+		var err error
+		if f.required, err = strconv.ParseBool(req); err != nil {
+			return fmt.Errorf("invalid required tag %q: %w", req, err)
+		}
+	}
+	f.description = tag.Get(tagDescription)
+	if str, ok := tag.Lookup(tagSources); ok {
+		var err error
+		if f.sources, err = option.ParseConfigSources(str); err != nil {
+			return fmt.Errorf("invalid sources tag %q: %w", str, err)
+		}
+	}
+	// environment variables are checked by generated code, they can be shared with option.WithSharedEnv
+	if f.flag != tagIgnored {
+		if other, ok := g.flags[f.flag]; ok {
+			return fmt.Errorf("flag name %q is already used by %s", f.flag, other)
+		}
+		g.flags[f.flag] = f.fullName
+	}
+	if f.hasDefault && f.kind != kindCustom && !f.bytes {
+		if err := checkDefault(f, f.def); err != nil {
+			return fmt.Errorf("invalid default value %q: %w", f.def, err)
+		}
+	}
+	g.fields = append(g.fields, f)
+	return nil
+}
+
+// uniqueSources removes duplicates of the sources in the same way as envconf does for the priority tag
+func uniqueSources(sources []option.ConfigSource) []option.ConfigSource {
+	result := make([]option.ConfigSource, 0, len(sources))
+	var mask option.ConfigSource
+	for _, cs := range sources {
+		if mask&cs == 0 {
+			result = append(result, cs)
+			mask |= cs
+		}
+	}
+	return result
+}
+
+// configName returns flag or env name of the field. `*` generates name from the field path
+func configName(f *field, tag reflect.StructTag, key, delim string, conv func(string) string) string {
+	name, ok := tag.Lookup(key)
+	if !ok || name == "" {
+		return tagIgnored
+	}
+	if name == valDefault {
+		return conv(strings.Join(append(f.parents[:len(f.parents):len(f.parents)], f.name), delim))
+	}
+	return name
+}
+
+// unmarshalMethod returns name of encoding.TextUnmarshaler or encoding.BinaryUnmarshaler method of t or *t.
+// Methods are looked up in the same order as envconf does
+func unmarshalMethod(t types.Type) string {
+	for _, tt := range []types.Type{t, types.NewPointer(t)} {
+		ms := types.NewMethodSet(tt)
+		for _, name := range []string{"UnmarshalText", "UnmarshalBinary"} {
+			sel := ms.Lookup(nil, name)
+			if sel == nil {
+				continue
+			}
+			if sig, ok := sel.Type().(*types.Signature); ok && isUnmarshalSignature(sig) {
+				return name
+			}
+		}
+	}
+	return ""
+}
+
+// valueKind returns kind of the type that is converted from a string.
+// Returns false if the type isn't converted from a string by envconf
+func valueKind(t types.Type) (fieldKind, string, bool) {
+	if method := unmarshalMethod(t); method != "" {
+		return kindCustom, method, true
+	}
+	b, ok := t.Underlying().(*types.Basic)
+	if !ok || b.Kind() == types.Uintptr || b.Kind() == types.UnsafePointer {
+		return kindBasic, "", false
+	}
+	if isDuration(t) {
+		return kindDuration, "", true
+	}
+	return kindBasic, "", true
+}
+
+func isUnmarshalSignature(sig *types.Signature) bool {
+	if sig.Params().Len() != 1 || sig.Results().Len() != 1 {
+		return false
+	}
+	bytes := types.NewSlice(types.Typ[types.Byte])
+	return types.Identical(sig.Params().At(0).Type(), bytes) &&
+		types.Identical(sig.Results().At(0).Type(), types.Universe.Lookup("error").Type())
+}
+
+func isDuration(t types.Type) bool {
+	n, ok := t.(*types.Named)
+	if !ok {
+		return false
+	}
+	obj := n.Obj()
+	return obj.Pkg() != nil && obj.Pkg().Path() == "time" && obj.Name() == "Duration"
+}
+
+// checkDefault verifies that default value can be converted into the type of the field.
+// Default value of a slice is checked by elements
+func checkDefault(f *field, value string) error {
+	if f.slice {
+		for _, item := range strings.Split(value, ",") {
+			if err := checkValue(f, item); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return checkValue(f, value)
+}
+
+func checkValue(f *field, value string) error {
+	value = strings.Trim(value, " ")
+	if f.kind == kindDuration {
+		_, err := time.ParseDuration(value)
+		return err
+	}
+	b := f.vt.Underlying().(*types.Basic)
+	var err error
+	switch info := b.Info(); {
+	case info&types.IsBoolean != 0:
+		_, err = strconv.ParseBool(value)
+	case info&types.IsUnsigned != 0:
+		_, err = strconv.ParseUint(value, 0, bitSize(b))
+	case info&types.IsInteger != 0:
+		_, err = strconv.ParseInt(value, 0, bitSize(b))
+	case info&types.IsFloat != 0:
+		_, err = strconv.ParseFloat(value, bitSize(b))
+	case info&types.IsComplex != 0:
+		_, err = strconv.ParseComplex(value, bitSize(b))
+	}
+	return err
+}
+
+func bitSize(b *types.Basic) int {
+	switch b.Kind() {
+	case types.Int8, types.Uint8:
+		return 8
+	case types.Int16, types.Uint16:
+		return 16
+	case types.Int32, types.Uint32, types.Float32:
+		return 32
+	case types.Complex64:
+		return 64
+	case types.Complex128:
+		return 128
+	case types.Int, types.Uint:
+		return strconv.IntSize
+	default:
+		return 64
+	}
+}
+
+// typeString returns type expression in the generated file and remembers required imports
+func (g *generator) typeString(t types.Type) string {
+	return types.TypeString(t, func(p *types.Package) string {
+		if p == g.pkg {
+			return ""
+		}
+		g.imports[p.Path()] = p.Name()
+		return p.Name()
+	})
+}
+
+func (g *generator) writeType(w *bytes.Buffer, name string) {
+	fieldsVar := "envconf" + name + "Fields"
+	fmt.Fprintf(w, "\nvar %s = [...]envconf.GenField{\n", fieldsVar)
+	for _, f := range g.fields {
+		g.writeGenField(w, f)
+	}
+	w.WriteString("}\n")
+
+	fmt.Fprintf(w, `
+// Parse%[1]s defines fields of data from flags, environment variables, external source and default values.
+// It behaves like envconf.Parse without reflection over %[1]s
+func Parse%[1]s(data *%[1]s, opts ...option.ClientOption) error {
+	return Parse%[1]sWith(envconf.New(), data, opts...)
+}
+
+// Parse%[1]sWith defines fields of data with ec. See Parse%[1]s
+func Parse%[1]sWith(ec *envconf.EnvConf, data *%[1]s, opts ...option.ClientOption) error {
+	if data == nil {
+		return envconf.ErrNilData
+	}
+	fields := &%[2]s
+	g := ec.Generated(opts...)
+	for i := range fields {
+		if err := g.Init(&fields[i]); err != nil {
+			return err
+		}
+	}
+	cfg := *data
+`, name, fieldsVar)
+	g.writeCopy(w)
+	fmt.Fprintf(w, `	var shadow %[1]s
+	var err error
+	if g.StrictPriority() {
+		err = g.Begin(&shadow)
+	} else {
+		err = g.Begin(&cfg)
+	}
+	if err != nil {
+		return err
+	}
+`, name)
+	for i, f := range g.fields {
+		g.writeDefine(w, i, f)
+	}
+	w.WriteString(`	if err = g.End(); err != nil {
+		return err
+	}
+	*data = cfg
+	return nil
+}
+`)
+}
+
+// writeCopy writes copying of pointers and slices, so data isn't modified if parsing fails
+func (g *generator) writeCopy(w *bytes.Buffer) {
+	for _, f := range g.fields {
+		sel := "cfg." + strings.Join(f.path, ".")
+		switch {
+		case f.ptr:
+			fmt.Fprintf(w, "\tif %[1]s != nil {\nv := *%[1]s\n%[1]s = &v\n}\n", sel)
+		case f.slice:
+			fmt.Fprintf(w, "\tif %[1]s != nil {\n%[1]s = append(make(%[2]s, 0, len(%[1]s)), %[1]s...)\n}\n", sel, g.typeString(f.t))
+		}
+	}
+}
+
+func (g *generator) writeGenField(w *bytes.Buffer, f *field) {
+	fmt.Fprintf(w, "{\n")
+	fmt.Fprintf(w, "Name: %q,\n", f.name)
+	fmt.Fprintf(w, "FullName: %q,\n", f.fullName)
+	fmt.Fprintf(w, "Path: %#v,\n", f.path)
+	if len(f.parents) != 0 {
+		fmt.Fprintf(w, "Parents: %#v,\n", f.parents)
+	}
+	fmt.Fprintf(w, "Type: reflect.TypeOf((*%s)(nil)).Elem(),\n", g.typeString(f.t))
+	fmt.Fprintf(w, "Flag: %q,\n", f.flag)
+	fmt.Fprintf(w, "Env: %q,\n", f.env)
+	if f.hasDefault {
+		fmt.Fprintf(w, "Default: %q,\nHasDefault: true,\n", f.def)
+		switch {
+		case f.kind != kindCustom || f.bytes:
+		case f.slice:
+			g.imports["strings"] = "strings"
+			fmt.Fprintf(w, "CheckDefault: func(s string) error {\nfor _, s := range strings.Split(s, \",\") {\n"+
+				"var v %s\nif err := v.%s([]byte(s)); err != nil {\nreturn err\n}\n}\nreturn nil\n},\n",
+				g.typeString(f.vt), f.method)
+		default:
+			fmt.Fprintf(w, "CheckDefault: func(s string) error {\nvar v %s\nreturn v.%s([]byte(s))\n},\n",
+				g.typeString(f.vt), f.method)
+		}
+	}
+	if f.required {
+		fmt.Fprintf(w, "Required: true,\n")
+	}
+	if f.slice {
+		fmt.Fprintf(w, "Slice: true,\n")
+	}
+	if f.secret {
+		fmt.Fprintf(w, "Secret: true,\n")
+	}
+	if f.description != "" {
+		fmt.Fprintf(w, "Description: %q,\n", f.description)
+	}
+	if f.sources != nil {
+		fmt.Fprintf(w, "Sources: %s,\n", sourcesExpr(f.sources))
+	}
+	if f.priority != nil {
+		fmt.Fprintf(w, "Priority: %s,\n", sourcesExpr(f.priority))
+	}
+	fmt.Fprintf(w, "},\n")
+}
+
+// sourceNames are names of option.ConfigSource constants
+var sourceNames = map[option.ConfigSource]string{
+	option.FlagVariable:   "FlagVariable",
+	option.EnvVariable:    "EnvVariable",
+	option.ExternalSource: "ExternalSource",
+	option.DefaultValue:   "DefaultValue",
+}
+
+// sourcesExpr returns expression of the sources slice in the generated file
+func sourcesExpr(sources []option.ConfigSource) string {
+	names := make([]string, len(sources))
+	for i, cs := range sources {
+		names[i] = "option." + sourceNames[cs]
+	}
+	return "[]option.ConfigSource{" + strings.Join(names, ", ") + "}"
+}
+
+// writeDefine writes resolution of the field. Conversions follow setFromString of envconf
+func (g *generator) writeDefine(w *bytes.Buffer, i int, f *field) {
+	sel := "cfg." + strings.Join(f.path, ".")
+	field := fmt.Sprintf("&fields[%d]", i)
+	fmt.Fprintf(w, "\t// %s\n", f.fullName)
+	fmt.Fprintf(w, `	if s, cs, err := g.Lookup(%[1]s); err != nil {
+		return err
+	} else if cs == option.ExternalSource {
+		if g.StrictPriority() {
+			%[2]s = shadow.%[3]s
+		}
+`, field, sel, strings.Join(f.path, "."))
+	if f.slice {
+		// elements of external source are reported as envconf does
+		fmt.Fprintf(w, "for j := range %[1]s {\ng.Defined(g.Item(%[2]s, j), %[1]s[j], cs)\n}\n", sel, field)
+		fmt.Fprintf(w, "g.Defined(%s, %s, cs)\n", field, sel)
+	}
+	w.WriteString("\t} else if cs != option.NoConfigValue {\n")
+	switch {
+	case f.bytes:
+		fmt.Fprintf(w, "%s = %s(s)\ng.Defined(%s, s, cs)\n", sel, g.typeString(f.t), field)
+	case f.slice:
+		g.imports["strings"] = "strings"
+		fmt.Fprintf(w, "items := strings.Split(s, \",\")\n%s = make(%s, len(items))\n", sel, g.typeString(f.t))
+		w.WriteString("for j, s := range items {\nitem := g.Item(" + field + ", j)\n")
+		g.writeValue(w, f, "item", sel+"[j]", sel+"[j]")
+		w.WriteString("}\n")
+		fmt.Fprintf(w, "g.Defined(%s, items, cs)\n", field)
+	case f.ptr:
+		// pointer is allocated only if value is defined
+		fmt.Fprintf(w, "if %s == nil {\n%s = new(%s)\n}\n", sel, sel, g.typeString(f.vt))
+		g.writeValue(w, f, field, "*"+sel, sel)
+	default:
+		g.writeValue(w, f, field, sel, sel)
+	}
+	if f.slice {
+		// Parse reports the first element if slice isn't defined
+		fmt.Fprintf(w, "\t} else if len(%s) != 0 {\ng.NotFound(g.Item(%s, 0))\n", sel, field)
+	}
+	w.WriteString("\t}\n")
+}
+
+// writeValue writes conversion of the string s into the value of the field.
+// dst is assigned with the value, recv is a receiver of the unmarshaling method
+func (g *generator) writeValue(w *bytes.Buffer, f *field, field, dst, recv string) {
+	switch f.kind {
+	case kindCustom:
+		fmt.Fprintf(w, "if err := %s.%s([]byte(s)); err != nil {\nreturn g.UnmarshalError(%s, s, cs, err)\n}\n",
+			recv, f.method, field)
+		fmt.Fprintf(w, "g.Defined(%s, s, cs)\n", field)
+	case kindDuration:
+		g.imports["strings"], g.imports["time"] = "strings", "time"
+		fmt.Fprintf(w, "v, err := time.ParseDuration(strings.Trim(s, \" \"))\n")
+		g.writeConversionError(w, field)
+		fmt.Fprintf(w, "%s = v\ng.Defined(%s, v.Nanoseconds(), cs)\n", dst, field)
+	default:
+		b := f.vt.Underlying().(*types.Basic)
+		if b.Info()&types.IsString != 0 {
+			fmt.Fprintf(w, "%s = %s\ng.Defined(%s, s, cs)\n", dst, g.convert(f.vt, "s", types.Typ[types.String]), field)
+			break
+		}
+		g.imports["strings"], g.imports["strconv"] = "strings", "strconv"
+		bits := strconv.Itoa(bitSize(b))
+		if b.Kind() == types.Int || b.Kind() == types.Uint {
+			bits = "strconv.IntSize"
+		}
+		var result types.Type
+		switch info := b.Info(); {
+		case info&types.IsBoolean != 0:
+			result = types.Typ[types.Bool]
+			fmt.Fprintf(w, "v, err := strconv.ParseBool(strings.Trim(s, \" \"))\n")
+		case info&types.IsUnsigned != 0:
+			result = types.Typ[types.Uint64]
+			fmt.Fprintf(w, "v, err := strconv.ParseUint(strings.Trim(s, \" \"), 0, %s)\n", bits)
+		case info&types.IsInteger != 0:
+			result = types.Typ[types.Int64]
+			fmt.Fprintf(w, "v, err := strconv.ParseInt(strings.Trim(s, \" \"), 0, %s)\n", bits)
+		case info&types.IsFloat != 0:
+			result = types.Typ[types.Float64]
+			fmt.Fprintf(w, "v, err := strconv.ParseFloat(strings.Trim(s, \" \"), %s)\n", bits)
+		case info&types.IsComplex != 0:
+			result = types.Typ[types.Complex128]
+			fmt.Fprintf(w, "v, err := strconv.ParseComplex(strings.Trim(s, \" \"), %s)\n", bits)
+		}
+		g.writeConversionError(w, field)
+		fmt.Fprintf(w, "%s = %s\ng.Defined(%s, v, cs)\n", dst, g.convert(f.vt, "v", result), field)
+	}
+}
+
+// convert returns expression converting value of the type from into t
+func (g *generator) convert(t types.Type, value string, from types.Type) string {
+	if types.Identical(t, from) {
+		return value
+	}
+	return g.typeString(t) + "(" + value + ")"
+}
+
+func (g *generator) writeConversionError(w *bytes.Buffer, field string) {
+	fmt.Fprintf(w, "if err != nil {\nreturn g.ConversionError(%s, s, cs, err)\n}\n", field)
+}
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestGenerate_CrossCheckIsUpToDate_Ok(t *testing.T) {
+	const dir = "internal/crosscheck"
+	files := map[string][]string{
+		"config_envconf.go": {"Config", "Required", "BadDefault"},
+		"repo_envconf.go": {"FlatDefault", "FlatEnv", "Nested", "RenamedParent", "RequiredValue", "SharedEnv",
+			"PriorityField", "PriorityInherited", "PriorityWithSources", "AllowedSource", "DisallowedSource",
+			"Slice", "ByteSlice", "StringPointer"},
+	}
+	for output, typeNames := range files {
+		expected, err := os.ReadFile(filepath.Join(dir, output))
+		if err != nil {
+			t.Fatal(err)
+		}
+		actual, err := generate(dir, output, typeNames)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(expected, actual) {
+			t.Fatalf("generated file %s is outdated, run go generate ./...", output)
+		}
+	}
+}
+
+func TestGenerate_Errors(t *testing.T) {
+	cases := []struct {
+		name string
+		src  string
+		err  string
+	}{
+		{"not found", "type Other struct{}", "type Config is not found"},
+		{"not struct", "type Config int", "type Config is not a struct"},
+		{"pointer to struct", "type Config struct{ Field *struct{ A int } }", "Config.Field: type *struct{A int} isn't supported"},
+		{"pointer to pointer", "type Config struct{ Field **int }", "Config.Field: type **int isn't supported"},
+		{"slice of slices", "type Config struct{ Field [][]int }", "Config.Field: type [][]int isn't supported"},
+		{"array", "type Config struct{ Field [2]int }", "Config.Field: type [2]int isn't supported"},
+		{"interface", "type Config struct{ Field interface{} }", "Config.Field: type interface{} isn't supported"},
+		{"slice default", "type Config struct{ Field []int `default:\"1,a\"` }", "Config.Field: invalid default value"},
+		{"nested", "type Config struct{ Inner struct{ Field map[string]int } }", "Config.Inner.Field: type map[string]int isn't supported"},
+		{"sources tag", "type Config struct{ Field int `sources:\"env,file\"` }", "Config.Field: invalid sources tag"},
+		{"priority tag", "type Config struct{ Inner struct{ Field int } `priority:\"file\"` }", "Config.Inner: invalid priority tag"},
+		{"required tag", "type Config struct{ Field int `required:\"yes\"` }", "Config.Field: invalid required tag"},
+		{"secret tag", "type Config struct{ Inner struct{ Field int } `secret:\"yes\"` }", "Config.Inner: invalid secret tag"},
+		{"default", "type Config struct{ Field uint8 `default:\"256\"` }", "Config.Field: invalid default value"},
+		{"duration default", "type Config struct{ Field time.Duration `default:\"5\"` }", "Config.Field: invalid default value"},
+		{"duplicate flag", "type Config struct{ A struct{ B int `flag:\"*\"` }; AB int `flag:\"a-b\"` }", `Config.AB: flag name "a-b" is already used by A.B`},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			dir := t.TempDir()
+			src := "package p\n\nimport \"time\"\n\nvar _ time.Duration\n\n" + tc.src + "\n"
+			if err := os.WriteFile(filepath.Join(dir, "p.go"), []byte(src), 0o644); err != nil {
+				t.Fatal(err)
+			}
+			_, err := generate(dir, "config_envconf.go", []string{"Config"})
+			if err == nil || !strings.Contains(err.Error(), tc.err) {
+				t.Fatalf("expected error %q but got %v", tc.err, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,81 @@
+// Package crosscheck contains structs parsed both by envconf.Parse and by the generated functions.
+// Tests compare results of both paths
+package crosscheck
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+//go:generate go run github.com/antonmashko/envconf/cmd/envconfgen -type Config,Required,BadDefault
+
+type Level int
+
+type Mode string
+
+// Addr is defined with encoding.TextUnmarshaler
+type Addr struct {
+	Host string
+	Port int
+}
+
+func (a *Addr) UnmarshalText(text []byte) error {
+	host, port, ok := strings.Cut(string(text), ":")
+	if !ok {
+		return errors.New("invalid address")
+	}
+	p, err := strconv.Atoi(port)
+	if err != nil {
+		return err
+	}
+	a.Host, a.Port = host, p
+	return nil
+}
+
+type HTTP struct {
+	Addr   string `flag:"*" env:"*" json:"addr"`
+	Secure bool   `env:"CC_HTTP_SECURE" json:"secure"`
+}
+
+type Config struct {
+	Name     string          `flag:"cc-name" env:"CC_NAME" default:"app" json:"name" description:"application name"`
+	Port     int             `env:"CC_PORT" default:"8080" json:"port"`
+	Debug    bool            `flag:"*" env:"CC_DEBUG" json:"debug"`
+	Rate     float64         `env:"CC_RATE" default:" 1.5 "`
+	Ratio    float32         `env:"CC_RATIO"`
+	Small    int8            `env:"CC_SMALL"`
+	Count    uint16          `env:"CC_COUNT" default:"0x10"`
+	Complex  complex128      `env:"CC_COMPLEX"`
+	Timeout  time.Duration   `env:"CC_TIMEOUT" default:"5s" json:"timeout"`
+	Level    Level           `env:"CC_LEVEL" default:"2"`
+	Mode     Mode            `env:"CC_MODE" json:"mode"`
+	Started  time.Time       `env:"CC_STARTED"`
+	Addr     Addr            `env:"CC_ADDR" default:"localhost:80"`
+	Password string          `env:"CC_PASSWORD" json:"password"`
+	Pin      int             `env:"CC_PIN" secret:"true"`
+	Hosts    []string        `env:"CC_HOSTS" default:"a,b" json:"hosts"`
+	Delays   []time.Duration `env:"CC_DELAYS"`
+	Backups  []Addr          `env:"CC_BACKUPS"`
+	Tokens   []string        `env:"CC_TOKENS" secret:"true"`
+	Limit    *int            `env:"CC_LIMIT" json:"limit"`
+	Delay    *time.Duration  `env:"CC_DELAY"`
+	Backup   *Addr           `env:"CC_BACKUP" default:"backup:80"`
+	DB       struct {
+		Host string `env:"*" json:"host"`
+		Port int    `env:"*" default:"5432" json:"port"`
+	} `json:"db"`
+	HTTP     HTTP `envconf:"web" json:"http"`
+	Callback func()
+	internal string
+}
+
+type Required struct {
+	Key   string `env:"CC_REQUIRED_KEY" required:"true"`
+	Value int    `env:"CC_REQUIRED_VALUE" default:"1"`
+}
+
+type BadDefault struct {
+	Addr Addr `env:"CC_BAD_DEFAULT" default:"invalid"`
+}
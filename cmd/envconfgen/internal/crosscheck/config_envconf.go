@@ -0,0 +1,884 @@
+// Code generated by envconfgen. DO NOT EDIT.
+
+package crosscheck
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/antonmashko/envconf"
+	"github.com/antonmashko/envconf/option"
+)
+
+var envconfConfigFields = [...]envconf.GenField{
+	{
+		Name:        "Name",
+		FullName:    "Name",
+		Path:        []string{"Name"},
+		Type:        reflect.TypeOf((*string)(nil)).Elem(),
+		Flag:        "cc-name",
+		Env:         "CC_NAME",
+		Default:     "app",
+		HasDefault:  true,
+		Description: "application name",
+	},
+	{
+		Name:       "Port",
+		FullName:   "Port",
+		Path:       []string{"Port"},
+		Type:       reflect.TypeOf((*int)(nil)).Elem(),
+		Flag:       "-",
+		Env:        "CC_PORT",
+		Default:    "8080",
+		HasDefault: true,
+	},
+	{
+		Name:     "Debug",
+		FullName: "Debug",
+		Path:     []string{"Debug"},
+		Type:     reflect.TypeOf((*bool)(nil)).Elem(),
+		Flag:     "debug",
+		Env:      "CC_DEBUG",
+	},
+	{
+		Name:       "Rate",
+		FullName:   "Rate",
+		Path:       []string{"Rate"},
+		Type:       reflect.TypeOf((*float64)(nil)).Elem(),
+		Flag:       "-",
+		Env:        "CC_RATE",
+		Default:    " 1.5 ",
+		HasDefault: true,
+	},
+	{
+		Name:     "Ratio",
+		FullName: "Ratio",
+		Path:     []string{"Ratio"},
+		Type:     reflect.TypeOf((*float32)(nil)).Elem(),
+		Flag:     "-",
+		Env:      "CC_RATIO",
+	},
+	{
+		Name:     "Small",
+		FullName: "Small",
+		Path:     []string{"Small"},
+		Type:     reflect.TypeOf((*int8)(nil)).Elem(),
+		Flag:     "-",
+		Env:      "CC_SMALL",
+	},
+	{
+		Name:       "Count",
+		FullName:   "Count",
+		Path:       []string{"Count"},
+		Type:       reflect.TypeOf((*uint16)(nil)).Elem(),
+		Flag:       "-",
+		Env:        "CC_COUNT",
+		Default:    "0x10",
+		HasDefault: true,
+	},
+	{
+		Name:     "Complex",
+		FullName: "Complex",
+		Path:     []string{"Complex"},
+		Type:     reflect.TypeOf((*complex128)(nil)).Elem(),
+		Flag:     "-",
+		Env:      "CC_COMPLEX",
+	},
+	{
+		Name:       "Timeout",
+		FullName:   "Timeout",
+		Path:       []string{"Timeout"},
+		Type:       reflect.TypeOf((*time.Duration)(nil)).Elem(),
+		Flag:       "-",
+		Env:        "CC_TIMEOUT",
+		Default:    "5s",
+		HasDefault: true,
+	},
+	{
+		Name:       "Level",
+		FullName:   "Level",
+		Path:       []string{"Level"},
+		Type:       reflect.TypeOf((*Level)(nil)).Elem(),
+		Flag:       "-",
+		Env:        "CC_LEVEL",
+		Default:    "2",
+		HasDefault: true,
+	},
+	{
+		Name:     "Mode",
+		FullName: "Mode",
+		Path:     []string{"Mode"},
+		Type:     reflect.TypeOf((*Mode)(nil)).Elem(),
+		Flag:     "-",
+		Env:      "CC_MODE",
+	},
+	{
+		Name:     "Started",
+		FullName: "Started",
+		Path:     []string{"Started"},
+		Type:     reflect.TypeOf((*time.Time)(nil)).Elem(),
+		Flag:     "-",
+		Env:      "CC_STARTED",
+	},
+	{
+		Name:       "Addr",
+		FullName:   "Addr",
+		Path:       []string{"Addr"},
+		Type:       reflect.TypeOf((*Addr)(nil)).Elem(),
+		Flag:       "-",
+		Env:        "CC_ADDR",
+		Default:    "localhost:80",
+		HasDefault: true,
+		CheckDefault: func(s string) error {
+			var v Addr
+			return v.UnmarshalText([]byte(s))
+		},
+	},
+	{
+		Name:     "Password",
+		FullName: "Password",
+		Path:     []string{"Password"},
+		Type:     reflect.TypeOf((*string)(nil)).Elem(),
+		Flag:     "-",
+		Env:      "CC_PASSWORD",
+	},
+	{
+		Name:     "Pin",
+		FullName: "Pin",
+		Path:     []string{"Pin"},
+		Type:     reflect.TypeOf((*int)(nil)).Elem(),
+		Flag:     "-",
+		Env:      "CC_PIN",
+		Secret:   true,
+	},
+	{
+		Name:       "Hosts",
+		FullName:   "Hosts",
+		Path:       []string{"Hosts"},
+		Type:       reflect.TypeOf((*[]string)(nil)).Elem(),
+		Flag:       "-",
+		Env:        "CC_HOSTS",
+		Default:    "a,b",
+		HasDefault: true,
+		Slice:      true,
+	},
+	{
+		Name:     "Delays",
+		FullName: "Delays",
+		Path:     []string{"Delays"},
+		Type:     reflect.TypeOf((*[]time.Duration)(nil)).Elem(),
+		Flag:     "-",
+		Env:      "CC_DELAYS",
+		Slice:    true,
+	},
+	{
+		Name:     "Backups",
+		FullName: "Backups",
+		Path:     []string{"Backups"},
+		Type:     reflect.TypeOf((*[]Addr)(nil)).Elem(),
+		Flag:     "-",
+		Env:      "CC_BACKUPS",
+		Slice:    true,
+	},
+	{
+		Name:     "Tokens",
+		FullName: "Tokens",
+		Path:     []string{"Tokens"},
+		Type:     reflect.TypeOf((*[]string)(nil)).Elem(),
+		Flag:     "-",
+		Env:      "CC_TOKENS",
+		Slice:    true,
+		Secret:   true,
+	},
+	{
+		Name:     "Limit",
+		FullName: "Limit",
+		Path:     []string{"Limit"},
+		Type:     reflect.TypeOf((**int)(nil)).Elem(),
+		Flag:     "-",
+		Env:      "CC_LIMIT",
+	},
+	{
+		Name:     "Delay",
+		FullName: "Delay",
+		Path:     []string{"Delay"},
+		Type:     reflect.TypeOf((**time.Duration)(nil)).Elem(),
+		Flag:     "-",
+		Env:      "CC_DELAY",
+	},
+	{
+		Name:       "Backup",
+		FullName:   "Backup",
+		Path:       []string{"Backup"},
+		Type:       reflect.TypeOf((**Addr)(nil)).Elem(),
+		Flag:       "-",
+		Env:        "CC_BACKUP",
+		Default:    "backup:80",
+		HasDefault: true,
+		CheckDefault: func(s string) error {
+			var v Addr
+			return v.UnmarshalText([]byte(s))
+		},
+	},
+	{
+		Name:     "Host",
+		FullName: "DB.Host",
+		Path:     []string{"DB", "Host"},
+		Parents:  []string{"DB"},
+		Type:     reflect.TypeOf((*string)(nil)).Elem(),
+		Flag:     "-",
+		Env:      "DB_HOST",
+	},
+	{
+		Name:       "Port",
+		FullName:   "DB.Port",
+		Path:       []string{"DB", "Port"},
+		Parents:    []string{"DB"},
+		Type:       reflect.TypeOf((*int)(nil)).Elem(),
+		Flag:       "-",
+		Env:        "DB_PORT",
+		Default:    "5432",
+		HasDefault: true,
+	},
+	{
+		Name:     "Addr",
+		FullName: "web.Addr",
+		Path:     []string{"HTTP", "Addr"},
+		Parents:  []string{"web"},
+		Type:     reflect.TypeOf((*string)(nil)).Elem(),
+		Flag:     "web-addr",
+		Env:      "WEB_ADDR",
+	},
+	{
+		Name:     "Secure",
+		FullName: "web.Secure",
+		Path:     []string{"HTTP", "Secure"},
+		Parents:  []string{"web"},
+		Type:     reflect.TypeOf((*bool)(nil)).Elem(),
+		Flag:     "-",
+		Env:      "CC_HTTP_SECURE",
+	},
+}
+
+// ParseConfig defines fields of data from flags, environment variables, external source and default values.
+// It behaves like envconf.Parse without reflection over Config
+func ParseConfig(data *Config, opts ...option.ClientOption) error {
+	return ParseConfigWith(envconf.New(), data, opts...)
+}
+
+// ParseConfigWith defines fields of data with ec. See ParseConfig
+func ParseConfigWith(ec *envconf.EnvConf, data *Config, opts ...option.ClientOption) error {
+	if data == nil {
+		return envconf.ErrNilData
+	}
+	fields := &envconfConfigFields
+	g := ec.Generated(opts...)
+	for i := range fields {
+		if err := g.Init(&fields[i]); err != nil {
+			return err
+		}
+	}
+	cfg := *data
+	if cfg.Hosts != nil {
+		cfg.Hosts = append(make([]string, 0, len(cfg.Hosts)), cfg.Hosts...)
+	}
+	if cfg.Delays != nil {
+		cfg.Delays = append(make([]time.Duration, 0, len(cfg.Delays)), cfg.Delays...)
+	}
+	if cfg.Backups != nil {
+		cfg.Backups = append(make([]Addr, 0, len(cfg.Backups)), cfg.Backups...)
+	}
+	if cfg.Tokens != nil {
+		cfg.Tokens = append(make([]string, 0, len(cfg.Tokens)), cfg.Tokens...)
+	}
+	if cfg.Limit != nil {
+		v := *cfg.Limit
+		cfg.Limit = &v
+	}
+	if cfg.Delay != nil {
+		v := *cfg.Delay
+		cfg.Delay = &v
+	}
+	if cfg.Backup != nil {
+		v := *cfg.Backup
+		cfg.Backup = &v
+	}
+	var shadow Config
+	var err error
+	if g.StrictPriority() {
+		err = g.Begin(&shadow)
+	} else {
+		err = g.Begin(&cfg)
+	}
+	if err != nil {
+		return err
+	}
+	// Name
+	if s, cs, err := g.Lookup(&fields[0]); err != nil {
+		return err
+	} else if cs == option.ExternalSource {
+		if g.StrictPriority() {
+			cfg.Name = shadow.Name
+		}
+	} else if cs != option.NoConfigValue {
+		cfg.Name = s
+		g.Defined(&fields[0], s, cs)
+	}
+	// Port
+	if s, cs, err := g.Lookup(&fields[1]); err != nil {
+		return err
+	} else if cs == option.ExternalSource {
+		if g.StrictPriority() {
+			cfg.Port = shadow.Port
+		}
+	} else if cs != option.NoConfigValue {
+		v, err := strconv.ParseInt(strings.Trim(s, " "), 0, strconv.IntSize)
+		if err != nil {
+			return g.ConversionError(&fields[1], s, cs, err)
+		}
+		cfg.Port = int(v)
+		g.Defined(&fields[1], v, cs)
+	}
+	// Debug
+	if s, cs, err := g.Lookup(&fields[2]); err != nil {
+		return err
+	} else if cs == option.ExternalSource {
+		if g.StrictPriority() {
+			cfg.Debug = shadow.Debug
+		}
+	} else if cs != option.NoConfigValue {
+		v, err := strconv.ParseBool(strings.Trim(s, " "))
+		if err != nil {
+			return g.ConversionError(&fields[2], s, cs, err)
+		}
+		cfg.Debug = v
+		g.Defined(&fields[2], v, cs)
+	}
+	// Rate
+	if s, cs, err := g.Lookup(&fields[3]); err != nil {
+		return err
+	} else if cs == option.ExternalSource {
+		if g.StrictPriority() {
+			cfg.Rate = shadow.Rate
+		}
+	} else if cs != option.NoConfigValue {
+		v, err := strconv.ParseFloat(strings.Trim(s, " "), 64)
+		if err != nil {
+			return g.ConversionError(&fields[3], s, cs, err)
+		}
+		cfg.Rate = v
+		g.Defined(&fields[3], v, cs)
+	}
+	// Ratio
+	if s, cs, err := g.Lookup(&fields[4]); err != nil {
+		return err
+	} else if cs == option.ExternalSource {
+		if g.StrictPriority() {
+			cfg.Ratio = shadow.Ratio
+		}
+	} else if cs != option.NoConfigValue {
+		v, err := strconv.ParseFloat(strings.Trim(s, " "), 32)
+		if err != nil {
+			return g.ConversionError(&fields[4], s, cs, err)
+		}
+		cfg.Ratio = float32(v)
+		g.Defined(&fields[4], v, cs)
+	}
+	// Small
+	if s, cs, err := g.Lookup(&fields[5]); err != nil {
+		return err
+	} else if cs == option.ExternalSource {
+		if g.StrictPriority() {
+			cfg.Small = shadow.Small
+		}
+	} else if cs != option.NoConfigValue {
+		v, err := strconv.ParseInt(strings.Trim(s, " "), 0, 8)
+		if err != nil {
+			return g.ConversionError(&fields[5], s, cs, err)
+		}
+		cfg.Small = int8(v)
+		g.Defined(&fields[5], v, cs)
+	}
+	// Count
+	if s, cs, err := g.Lookup(&fields[6]); err != nil {
+		return err
+	} else if cs == option.ExternalSource {
+		if g.StrictPriority() {
+			cfg.Count = shadow.Count
+		}
+	} else if cs != option.NoConfigValue {
+		v, err := strconv.ParseUint(strings.Trim(s, " "), 0, 16)
+		if err != nil {
+			return g.ConversionError(&fields[6], s, cs, err)
+		}
+		cfg.Count = uint16(v)
+		g.Defined(&fields[6], v, cs)
+	}
+	// Complex
+	if s, cs, err := g.Lookup(&fields[7]); err != nil {
+		return err
+	} else if cs == option.ExternalSource {
+		if g.StrictPriority() {
+			cfg.Complex = shadow.Complex
+		}
+	} else if cs != option.NoConfigValue {
+		v, err := strconv.ParseComplex(strings.Trim(s, " "), 128)
+		if err != nil {
+			return g.ConversionError(&fields[7], s, cs, err)
+		}
+		cfg.Complex = v
+		g.Defined(&fields[7], v, cs)
+	}
+	// Timeout
+	if s, cs, err := g.Lookup(&fields[8]); err != nil {
+		return err
+	} else if cs == option.ExternalSource {
+		if g.StrictPriority() {
+			cfg.Timeout = shadow.Timeout
+		}
+	} else if cs != option.NoConfigValue {
+		v, err := time.ParseDuration(strings.Trim(s, " "))
+		if err != nil {
+			return g.ConversionError(&fields[8], s, cs, err)
+		}
+		cfg.Timeout = v
+		g.Defined(&fields[8], v.Nanoseconds(), cs)
+	}
+	// Level
+	if s, cs, err := g.Lookup(&fields[9]); err != nil {
+		return err
+	} else if cs == option.ExternalSource {
+		if g.StrictPriority() {
+			cfg.Level = shadow.Level
+		}
+	} else if cs != option.NoConfigValue {
+		v, err := strconv.ParseInt(strings.Trim(s, " "), 0, strconv.IntSize)
+		if err != nil {
+			return g.ConversionError(&fields[9], s, cs, err)
+		}
+		cfg.Level = Level(v)
+		g.Defined(&fields[9], v, cs)
+	}
+	// Mode
+	if s, cs, err := g.Lookup(&fields[10]); err != nil {
+		return err
+	} else if cs == option.ExternalSource {
+		if g.StrictPriority() {
+			cfg.Mode = shadow.Mode
+		}
+	} else if cs != option.NoConfigValue {
+		cfg.Mode = Mode(s)
+		g.Defined(&fields[10], s, cs)
+	}
+	// Started
+	if s, cs, err := g.Lookup(&fields[11]); err != nil {
+		return err
+	} else if cs == option.ExternalSource {
+		if g.StrictPriority() {
+			cfg.Started = shadow.Started
+		}
+	} else if cs != option.NoConfigValue {
+		if err := cfg.Started.UnmarshalText([]byte(s)); err != nil {
+			return g.UnmarshalError(&fields[11], s, cs, err)
+		}
+		g.Defined(&fields[11], s, cs)
+	}
+	// Addr
+	if s, cs, err := g.Lookup(&fields[12]); err != nil {
+		return err
+	} else if cs == option.ExternalSource {
+		if g.StrictPriority() {
+			cfg.Addr = shadow.Addr
+		}
+	} else if cs != option.NoConfigValue {
+		if err := cfg.Addr.UnmarshalText([]byte(s)); err != nil {
+			return g.UnmarshalError(&fields[12], s, cs, err)
+		}
+		g.Defined(&fields[12], s, cs)
+	}
+	// Password
+	if s, cs, err := g.Lookup(&fields[13]); err != nil {
+		return err
+	} else if cs == option.ExternalSource {
+		if g.StrictPriority() {
+			cfg.Password = shadow.Password
+		}
+	} else if cs != option.NoConfigValue {
+		cfg.Password = s
+		g.Defined(&fields[13], s, cs)
+	}
+	// Pin
+	if s, cs, err := g.Lookup(&fields[14]); err != nil {
+		return err
+	} else if cs == option.ExternalSource {
+		if g.StrictPriority() {
+			cfg.Pin = shadow.Pin
+		}
+	} else if cs != option.NoConfigValue {
+		v, err := strconv.ParseInt(strings.Trim(s, " "), 0, strconv.IntSize)
+		if err != nil {
+			return g.ConversionError(&fields[14], s, cs, err)
+		}
+		cfg.Pin = int(v)
+		g.Defined(&fields[14], v, cs)
+	}
+	// Hosts
+	if s, cs, err := g.Lookup(&fields[15]); err != nil {
+		return err
+	} else if cs == option.ExternalSource {
+		if g.StrictPriority() {
+			cfg.Hosts = shadow.Hosts
+		}
+		for j := range cfg.Hosts {
+			g.Defined(g.Item(&fields[15], j), cfg.Hosts[j], cs)
+		}
+		g.Defined(&fields[15], cfg.Hosts, cs)
+	} else if cs != option.NoConfigValue {
+		items := strings.Split(s, ",")
+		cfg.Hosts = make([]string, len(items))
+		for j, s := range items {
+			item := g.Item(&fields[15], j)
+			cfg.Hosts[j] = s
+			g.Defined(item, s, cs)
+		}
+		g.Defined(&fields[15], items, cs)
+	} else if len(cfg.Hosts) != 0 {
+		g.NotFound(g.Item(&fields[15], 0))
+	}
+	// Delays
+	if s, cs, err := g.Lookup(&fields[16]); err != nil {
+		return err
+	} else if cs == option.ExternalSource {
+		if g.StrictPriority() {
+			cfg.Delays = shadow.Delays
+		}
+		for j := range cfg.Delays {
+			g.Defined(g.Item(&fields[16], j), cfg.Delays[j], cs)
+		}
+		g.Defined(&fields[16], cfg.Delays, cs)
+	} else if cs != option.NoConfigValue {
+		items := strings.Split(s, ",")
+		cfg.Delays = make([]time.Duration, len(items))
+		for j, s := range items {
+			item := g.Item(&fields[16], j)
+			v, err := time.ParseDuration(strings.Trim(s, " "))
+			if err != nil {
+				return g.ConversionError(item, s, cs, err)
+			}
+			cfg.Delays[j] = v
+			g.Defined(item, v.Nanoseconds(), cs)
+		}
+		g.Defined(&fields[16], items, cs)
+	} else if len(cfg.Delays) != 0 {
+		g.NotFound(g.Item(&fields[16], 0))
+	}
+	// Backups
+	if s, cs, err := g.Lookup(&fields[17]); err != nil {
+		return err
+	} else if cs == option.ExternalSource {
+		if g.StrictPriority() {
+			cfg.Backups = shadow.Backups
+		}
+		for j := range cfg.Backups {
+			g.Defined(g.Item(&fields[17], j), cfg.Backups[j], cs)
+		}
+		g.Defined(&fields[17], cfg.Backups, cs)
+	} else if cs != option.NoConfigValue {
+		items := strings.Split(s, ",")
+		cfg.Backups = make([]Addr, len(items))
+		for j, s := range items {
+			item := g.Item(&fields[17], j)
+			if err := cfg.Backups[j].UnmarshalText([]byte(s)); err != nil {
+				return g.UnmarshalError(item, s, cs, err)
+			}
+			g.Defined(item, s, cs)
+		}
+		g.Defined(&fields[17], items, cs)
+	} else if len(cfg.Backups) != 0 {
+		g.NotFound(g.Item(&fields[17], 0))
+	}
+	// Tokens
+	if s, cs, err := g.Lookup(&fields[18]); err != nil {
+		return err
+	} else if cs == option.ExternalSource {
+		if g.StrictPriority() {
+			cfg.Tokens = shadow.Tokens
+		}
+		for j := range cfg.Tokens {
+			g.Defined(g.Item(&fields[18], j), cfg.Tokens[j], cs)
+		}
+		g.Defined(&fields[18], cfg.Tokens, cs)
+	} else if cs != option.NoConfigValue {
+		items := strings.Split(s, ",")
+		cfg.Tokens = make([]string, len(items))
+		for j, s := range items {
+			item := g.Item(&fields[18], j)
+			cfg.Tokens[j] = s
+			g.Defined(item, s, cs)
+		}
+		g.Defined(&fields[18], items, cs)
+	} else if len(cfg.Tokens) != 0 {
+		g.NotFound(g.Item(&fields[18], 0))
+	}
+	// Limit
+	if s, cs, err := g.Lookup(&fields[19]); err != nil {
+		return err
+	} else if cs == option.ExternalSource {
+		if g.StrictPriority() {
+			cfg.Limit = shadow.Limit
+		}
+	} else if cs != option.NoConfigValue {
+		if cfg.Limit == nil {
+			cfg.Limit = new(int)
+		}
+		v, err := strconv.ParseInt(strings.Trim(s, " "), 0, strconv.IntSize)
+		if err != nil {
+			return g.ConversionError(&fields[19], s, cs, err)
+		}
+		*cfg.Limit = int(v)
+		g.Defined(&fields[19], v, cs)
+	}
+	// Delay
+	if s, cs, err := g.Lookup(&fields[20]); err != nil {
+		return err
+	} else if cs == option.ExternalSource {
+		if g.StrictPriority() {
+			cfg.Delay = shadow.Delay
+		}
+	} else if cs != option.NoConfigValue {
+		if cfg.Delay == nil {
+			cfg.Delay = new(time.Duration)
+		}
+		v, err := time.ParseDuration(strings.Trim(s, " "))
+		if err != nil {
+			return g.ConversionError(&fields[20], s, cs, err)
+		}
+		*cfg.Delay = v
+		g.Defined(&fields[20], v.Nanoseconds(), cs)
+	}
+	// Backup
+	if s, cs, err := g.Lookup(&fields[21]); err != nil {
+		return err
+	} else if cs == option.ExternalSource {
+		if g.StrictPriority() {
+			cfg.Backup = shadow.Backup
+		}
+	} else if cs != option.NoConfigValue {
+		if cfg.Backup == nil {
+			cfg.Backup = new(Addr)
+		}
+		if err := cfg.Backup.UnmarshalText([]byte(s)); err != nil {
+			return g.UnmarshalError(&fields[21], s, cs, err)
+		}
+		g.Defined(&fields[21], s, cs)
+	}
+	// DB.Host
+	if s, cs, err := g.Lookup(&fields[22]); err != nil {
+		return err
+	} else if cs == option.ExternalSource {
+		if g.StrictPriority() {
+			cfg.DB.Host = shadow.DB.Host
+		}
+	} else if cs != option.NoConfigValue {
+		cfg.DB.Host = s
+		g.Defined(&fields[22], s, cs)
+	}
+	// DB.Port
+	if s, cs, err := g.Lookup(&fields[23]); err != nil {
+		return err
+	} else if cs == option.ExternalSource {
+		if g.StrictPriority() {
+			cfg.DB.Port = shadow.DB.Port
+		}
+	} else if cs != option.NoConfigValue {
+		v, err := strconv.ParseInt(strings.Trim(s, " "), 0, strconv.IntSize)
+		if err != nil {
+			return g.ConversionError(&fields[23], s, cs, err)
+		}
+		cfg.DB.Port = int(v)
+		g.Defined(&fields[23], v, cs)
+	}
+	// web.Addr
+	if s, cs, err := g.Lookup(&fields[24]); err != nil {
+		return err
+	} else if cs == option.ExternalSource {
+		if g.StrictPriority() {
+			cfg.HTTP.Addr = shadow.HTTP.Addr
+		}
+	} else if cs != option.NoConfigValue {
+		cfg.HTTP.Addr = s
+		g.Defined(&fields[24], s, cs)
+	}
+	// web.Secure
+	if s, cs, err := g.Lookup(&fields[25]); err != nil {
+		return err
+	} else if cs == option.ExternalSource {
+		if g.StrictPriority() {
+			cfg.HTTP.Secure = shadow.HTTP.Secure
+		}
+	} else if cs != option.NoConfigValue {
+		v, err := strconv.ParseBool(strings.Trim(s, " "))
+		if err != nil {
+			return g.ConversionError(&fields[25], s, cs, err)
+		}
+		cfg.HTTP.Secure = v
+		g.Defined(&fields[25], v, cs)
+	}
+	if err = g.End(); err != nil {
+		return err
+	}
+	*data = cfg
+	return nil
+}
+
+var envconfRequiredFields = [...]envconf.GenField{
+	{
+		Name:     "Key",
+		FullName: "Key",
+		Path:     []string{"Key"},
+		Type:     reflect.TypeOf((*string)(nil)).Elem(),
+		Flag:     "-",
+		Env:      "CC_REQUIRED_KEY",
+		Required: true,
+	},
+	{
+		Name:       "Value",
+		FullName:   "Value",
+		Path:       []string{"Value"},
+		Type:       reflect.TypeOf((*int)(nil)).Elem(),
+		Flag:       "-",
+		Env:        "CC_REQUIRED_VALUE",
+		Default:    "1",
+		HasDefault: true,
+	},
+}
+
+// ParseRequired defines fields of data from flags, environment variables, external source and default values.
+// It behaves like envconf.Parse without reflection over Required
+func ParseRequired(data *Required, opts ...option.ClientOption) error {
+	return ParseRequiredWith(envconf.New(), data, opts...)
+}
+
+// ParseRequiredWith defines fields of data with ec. See ParseRequired
+func ParseRequiredWith(ec *envconf.EnvConf, data *Required, opts ...option.ClientOption) error {
+	if data == nil {
+		return envconf.ErrNilData
+	}
+	fields := &envconfRequiredFields
+	g := ec.Generated(opts...)
+	for i := range fields {
+		if err := g.Init(&fields[i]); err != nil {
+			return err
+		}
+	}
+	cfg := *data
+	var shadow Required
+	var err error
+	if g.StrictPriority() {
+		err = g.Begin(&shadow)
+	} else {
+		err = g.Begin(&cfg)
+	}
+	if err != nil {
+		return err
+	}
+	// Key
+	if s, cs, err := g.Lookup(&fields[0]); err != nil {
+		return err
+	} else if cs == option.ExternalSource {
+		if g.StrictPriority() {
+			cfg.Key = shadow.Key
+		}
+	} else if cs != option.NoConfigValue {
+		cfg.Key = s
+		g.Defined(&fields[0], s, cs)
+	}
+	// Value
+	if s, cs, err := g.Lookup(&fields[1]); err != nil {
+		return err
+	} else if cs == option.ExternalSource {
+		if g.StrictPriority() {
+			cfg.Value = shadow.Value
+		}
+	} else if cs != option.NoConfigValue {
+		v, err := strconv.ParseInt(strings.Trim(s, " "), 0, strconv.IntSize)
+		if err != nil {
+			return g.ConversionError(&fields[1], s, cs, err)
+		}
+		cfg.Value = int(v)
+		g.Defined(&fields[1], v, cs)
+	}
+	if err = g.End(); err != nil {
+		return err
+	}
+	*data = cfg
+	return nil
+}
+
+var envconfBadDefaultFields = [...]envconf.GenField{
+	{
+		Name:       "Addr",
+		FullName:   "Addr",
+		Path:       []string{"Addr"},
+		Type:       reflect.TypeOf((*Addr)(nil)).Elem(),
+		Flag:       "-",
+		Env:        "CC_BAD_DEFAULT",
+		Default:    "invalid",
+		HasDefault: true,
+		CheckDefault: func(s string) error {
+			var v Addr
+			return v.UnmarshalText([]byte(s))
+		},
+	},
+}
+
+// ParseBadDefault defines fields of data from flags, environment variables, external source and default values.
+// It behaves like envconf.Parse without reflection over BadDefault
+func ParseBadDefault(data *BadDefault, opts ...option.ClientOption) error {
+	return ParseBadDefaultWith(envconf.New(), data, opts...)
+}
+
+// ParseBadDefaultWith defines fields of data with ec. See ParseBadDefault
+func ParseBadDefaultWith(ec *envconf.EnvConf, data *BadDefault, opts ...option.ClientOption) error {
+	if data == nil {
+		return envconf.ErrNilData
+	}
+	fields := &envconfBadDefaultFields
+	g := ec.Generated(opts...)
+	for i := range fields {
+		if err := g.Init(&fields[i]); err != nil {
+			return err
+		}
+	}
+	cfg := *data
+	var shadow BadDefault
+	var err error
+	if g.StrictPriority() {
+		err = g.Begin(&shadow)
+	} else {
+		err = g.Begin(&cfg)
+	}
+	if err != nil {
+		return err
+	}
+	// Addr
+	if s, cs, err := g.Lookup(&fields[0]); err != nil {
+		return err
+	} else if cs == option.ExternalSource {
+		if g.StrictPriority() {
+			cfg.Addr = shadow.Addr
+		}
+	} else if cs != option.NoConfigValue {
+		if err := cfg.Addr.UnmarshalText([]byte(s)); err != nil {
+			return g.UnmarshalError(&fields[0], s, cs, err)
+		}
+		g.Defined(&fields[0], s, cs)
+	}
+	if err = g.End(); err != nil {
+		return err
+	}
+	*data = cfg
+	return nil
+}
@@ -0,0 +1,197 @@
+package crosscheck
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/antonmashko/envconf"
+	jsonconf "github.com/antonmashko/envconf/external/json"
+	"github.com/antonmashko/envconf/jsonschema"
+	"github.com/antonmashko/envconf/option"
+)
+
+type printer struct {
+	lines []string
+}
+
+func (p *printer) Print(args ...interface{}) {
+	p.lines = append(p.lines, fmt.Sprint(args...))
+}
+
+type crossCase struct {
+	name string
+	env  map[string]string
+	args []string
+	opts func() []option.ClientOption
+}
+
+// crossCheck parses configuration with envconf.Parse and with the generated function
+// and compares data, errors and log of both paths.
+// The same EnvConf is used for both paths, so flags are registered once
+func crossCheck[T any](t *testing.T, ec *envconf.EnvConf, tc crossCase, data func() *T, parse func(*envconf.EnvConf, *T, ...option.ClientOption) error) {
+	t.Helper()
+	for k, v := range tc.env {
+		t.Setenv(k, v)
+	}
+	if tc.args != nil {
+		args := os.Args
+		os.Args = append([]string{args[0]}, tc.args...)
+		defer func() { os.Args = args }()
+	}
+	run := func(f func(*T, ...option.ClientOption) error) (*T, error, []string) {
+		var opts []option.ClientOption
+		if tc.opts != nil {
+			opts = tc.opts()
+		}
+		p := &printer{}
+		d := data()
+		err := f(d, append(opts, option.WithLog(p))...)
+		return d, err, p.lines
+	}
+	expected, expectedErr, expectedLog := run(func(d *T, opts ...option.ClientOption) error {
+		return ec.Parse(d, opts...)
+	})
+	actual, actualErr, actualLog := run(func(d *T, opts ...option.ClientOption) error {
+		return parse(ec, d, opts...)
+	})
+	if fmt.Sprint(expectedErr) != fmt.Sprint(actualErr) {
+		t.Fatalf("unexpected error. expected=%v actual=%v", expectedErr, actualErr)
+	}
+	if !reflect.DeepEqual(expected, actual) {
+		t.Fatalf("unexpected result.\nexpected=%+v\nactual=%+v", expected, actual)
+	}
+	if !reflect.DeepEqual(expectedLog, actualLog) {
+		t.Fatalf("unexpected log.\nexpected=%q\nactual=%q", expectedLog, actualLog)
+	}
+}
+
+func newConfig() *Config {
+	return &Config{Password: "keep", internal: "internal"}
+}
+
+const externalJSON = `{"name": "ext", "port": 9090, "mode": "slow", "timeout": 1000,
+	"db": {"host": "ext-db", "port": 1}, "http": {"addr": "ext:1", "secure": true}, "hosts": ["h1", "h2"], "limit": 3}`
+
+func TestCrossCheck_Config(t *testing.T) {
+	external := func(opts ...option.ClientOption) func() []option.ClientOption {
+		return func() []option.ClientOption {
+			return append(opts, option.WithExternal(jsonconf.Json(externalJSON)))
+		}
+	}
+	cases := []crossCase{
+		{name: "defaults"},
+		{
+			name: "env",
+			env: map[string]string{
+				"CC_NAME": " name ", "CC_PORT": " 0x1F ", "CC_DEBUG": "true", "CC_RATE": "2.5",
+				"CC_RATIO": "0.25", "CC_SMALL": "-8", "CC_COUNT": "7", "CC_COMPLEX": "1+2i",
+				"CC_TIMEOUT": " 1m ", "CC_LEVEL": "3", "CC_MODE": " fast ", "CC_STARTED": "2024-01-02T03:04:05Z",
+				"CC_ADDR": "example.com:443", "CC_PASSWORD": "hunter2", "CC_PIN": "1234",
+				"DB_HOST": "db", "DB_PORT": "5433", "WEB_ADDR": ":80", "CC_HTTP_SECURE": "1",
+				"CC_HOSTS": "x, y", "CC_DELAYS": "1s,2m", "CC_BACKUPS": "a:1,b:2", "CC_TOKENS": "t1,t2",
+				"CC_LIMIT": " 10 ", "CC_DELAY": "3s", "CC_BACKUP": "c:3",
+			},
+		},
+		{name: "conversion error", env: map[string]string{"CC_PORT": "abc"}},
+		{name: "overflow", env: map[string]string{"CC_SMALL": "300"}},
+		{name: "duration error", env: map[string]string{"CC_TIMEOUT": "5"}},
+		{name: "secret conversion error", env: map[string]string{"CC_PIN": "hunter2"}},
+		{name: "unmarshal error", env: map[string]string{"CC_ADDR": "invalid"}},
+		{name: "nested error", env: map[string]string{"DB_PORT": "invalid"}},
+		{name: "element error", env: map[string]string{"CC_DELAYS": "1s,5"}},
+		{name: "unmarshal element error", env: map[string]string{"CC_BACKUPS": "a:1,invalid"}},
+		{name: "pointer error", env: map[string]string{"CC_LIMIT": "x"}},
+		{name: "external", env: map[string]string{"CC_PORT": "1"}, opts: external()},
+		{name: "strict priority", env: map[string]string{"DB_HOST": "env-db"}, opts: external(option.WithStrictPriority())},
+		{
+			name: "priority order",
+			env:  map[string]string{"CC_PORT": "1", "CC_NAME": "env"},
+			opts: external(option.WithPriorityOrder(option.ExternalSource, option.EnvVariable, option.DefaultValue)),
+		},
+		{
+			name: "external injection",
+			env:  map[string]string{"CC_INJECTED": "injected"},
+			opts: func() []option.ClientOption {
+				return []option.ClientOption{
+					option.WithExternal(jsonconf.Json(`{"name": "${ .env.CC_INJECTED }", "mode": "${ .env.CC_MISSING }"}`)),
+					option.WithExternalInjection(),
+				}
+			},
+		},
+		{
+			name: "strict external",
+			opts: func() []option.ClientOption {
+				return []option.ClientOption{
+					option.WithExternal(jsonconf.Json(`{"nmae": "ext"}`)),
+					option.WithStrictExternal(),
+				}
+			},
+		},
+		{
+			name: "strict env",
+			env:  map[string]string{"CC_UNKNOWN": "1", "CC_PORT": "1"},
+			opts: func() []option.ClientOption {
+				return []option.ClientOption{option.WithStrictEnv("CC_")}
+			},
+		},
+		{
+			name: "source policy",
+			env:  map[string]string{"CC_PIN": "1"},
+			opts: func() []option.ClientOption {
+				return []option.ClientOption{option.WithSecretSources(option.DefaultValue)}
+			},
+		},
+		{
+			name: "flag parsed error",
+			opts: func() []option.ClientOption {
+				return []option.ClientOption{option.WithFlagParsed(func() error { return errors.New("flag parsed") })}
+			},
+		},
+		{
+			// flags stay defined after parsing, so the case is the last one
+			name: "flags",
+			env:  map[string]string{"CC_NAME": "env", "WEB_ADDR": "env"},
+			args: []string{"-cc-name=flag", "-debug=true", "-web-addr", ":9090"},
+		},
+	}
+	ec := envconf.New()
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			crossCheck(t, ec, tc, newConfig, ParseConfigWith)
+		})
+	}
+}
+
+func TestCrossCheck_Required(t *testing.T) {
+	cases := []crossCase{
+		{name: "missing"},
+		{name: "defined", env: map[string]string{"CC_REQUIRED_KEY": "key", "CC_REQUIRED_VALUE": "2"}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			crossCheck(t, envconf.New(), tc, func() *Required { return &Required{} }, ParseRequiredWith)
+		})
+	}
+}
+
+func TestCrossCheck_BadDefault(t *testing.T) {
+	crossCheck(t, envconf.New(), crossCase{}, func() *BadDefault { return &BadDefault{} }, ParseBadDefaultWith)
+}
+
+func TestGenerated_NilData_Err(t *testing.T) {
+	if err := ParseConfig(nil); err != envconf.ErrNilData {
+		t.Fatalf("expected ErrNilData but got %v", err)
+	}
+}
+
+func TestGenerated_SchemaValidation_Err(t *testing.T) {
+	err := ParseRequired(&Required{},
+		option.WithExternal(jsonconf.Json(`{}`)),
+		option.WithSchemaValidation(&jsonschema.Schema{}))
+	if err != envconf.ErrGeneratedSchema {
+		t.Fatalf("expected ErrGeneratedSchema but got %v", err)
+	}
+}
@@ -0,0 +1,120 @@
+package crosscheck
+
+// Structs below are copied from tests of envconf package, so generated code is checked
+// against the same configurations as envconf.Parse
+
+//go:generate go run github.com/antonmashko/envconf/cmd/envconfgen -type FlatDefault,FlatEnv,Nested,RenamedParent,RequiredValue,SharedEnv,PriorityField,PriorityInherited,PriorityWithSources,AllowedSource,DisallowedSource,Slice,ByteSlice,StringPointer -output repo_envconf.go
+
+// FlatDefault is from TestPrimitive_ParseFlatStructWithAllPrimitivesFromDefault_Ok
+type FlatDefault struct {
+	Field1  bool    `default:"true"`
+	Field2  int     `default:"1"`
+	Field3  int8    `default:"2"`
+	Field4  int16   `default:"3"`
+	Field5  int32   `default:"4"`
+	Field6  int64   `default:"5"`
+	Field7  uint    `default:"6"`
+	Field8  uint8   `default:"7"`
+	Field9  uint16  `default:"8"`
+	Field10 uint32  `default:"9"`
+	Field11 uint64  `default:"10"`
+	Field12 float32 `default:"11"`
+	Field13 float64 `default:"12"`
+	Field14 string  `default:"13"`
+}
+
+// FlatEnv is from TestParseFlatStructWithAllPrimitivesFromEnv_Ok
+type FlatEnv struct {
+	Field1  bool    `env:"TEST_FIELD_1"`
+	Field2  int     `env:"TEST_FIELD_2"`
+	Field3  int8    `env:"TEST_FIELD_3"`
+	Field4  int16   `env:"TEST_FIELD_4"`
+	Field5  int32   `env:"TEST_FIELD_5"`
+	Field6  int64   `env:"TEST_FIELD_6"`
+	Field7  uint    `env:"TEST_FIELD_7"`
+	Field8  uint8   `env:"TEST_FIELD_8"`
+	Field9  uint16  `env:"TEST_FIELD_9"`
+	Field10 uint32  `env:"TEST_FIELD_10"`
+	Field11 uint64  `env:"TEST_FIELD_11"`
+	Field12 float32 `env:"TEST_FIELD_12"`
+	Field13 float64 `env:"TEST_FIELD_13"`
+	Field14 string  `env:"TEST_FIELD_14"`
+}
+
+// Nested is from TestParseFieldInNNestedStruct_Ok
+type Nested struct {
+	Inner1 struct {
+		Inner12 struct {
+		}
+		Inner13 struct {
+			Inner131 struct {
+				Field1 int `default:"123"`
+			}
+		}
+	}
+	Inner2 struct{}
+}
+
+// RenamedParent is from TestParse_AutoGeneratedEnvNames_Ok
+type RenamedParent struct {
+	Inner1 struct {
+		Field1 string `env:"*"`
+	} `envconf:"INNER"`
+}
+
+// RequiredValue is from TestPrimitive_RequiredValue_Ok
+type RequiredValue struct {
+	Field1 string `env:"TEST_FIELD1" required:"true"`
+}
+
+// SharedEnv is from TestNames_SharedEnv_Ok
+type SharedEnv struct {
+	Inner struct {
+		Field string `env:"TEST_NAMES_SHARED_ENV"`
+	}
+	Field string `env:"TEST_NAMES_SHARED_ENV"`
+}
+
+// PriorityField is from TestPriorityTag_Field_Ok
+type PriorityField struct {
+	Field1 string `env:"TEST_PRIORITY_TAG_FIELD1" json:"field1" priority:"external,env,flag,default"`
+	Field2 string `env:"TEST_PRIORITY_TAG_FIELD2" json:"field2"`
+}
+
+// PriorityInherited is from TestPriorityTag_InheritedFromStruct_Ok
+type PriorityInherited struct {
+	Inner struct {
+		Field1 string `env:"TEST_PRIORITY_TAG_INNER_FIELD1" default:"from-default"`
+		Field2 string `env:"TEST_PRIORITY_TAG_INNER_FIELD2" default:"from-default" priority:"env"`
+	} `priority:"default,env"`
+}
+
+// PriorityWithSources is from TestPriorityTag_WithSources_Ok
+type PriorityWithSources struct {
+	Field string `default:"value" priority:"external,env,default" sources:"env,default"`
+}
+
+// AllowedSource is from TestSources_AllowedSource_Ok
+type AllowedSource struct {
+	Field string `env:"TEST_SOURCES_ALLOWED" sources:"env,external"`
+}
+
+// DisallowedSource is from TestSources_DisallowedSourceProvidedValue_Err
+type DisallowedSource struct {
+	Field string `json:"field" default:"value" sources:"env,default"`
+}
+
+// Slice is from TestParse_Slice_Ok
+type Slice struct {
+	Field []int `env:"TEST_PARSE_SLICE_OK"`
+}
+
+// ByteSlice is from TestParse_StringByteSlice_Ok
+type ByteSlice struct {
+	Field []byte `default:"abc"`
+}
+
+// StringPointer is from TestStringPointer_InitFromDefault_Ok
+type StringPointer struct {
+	Field *string `default:"test"`
+}
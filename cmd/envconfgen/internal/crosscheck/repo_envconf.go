@@ -0,0 +1,1575 @@
+// Code generated by envconfgen. DO NOT EDIT.
+
+package crosscheck
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/antonmashko/envconf"
+	"github.com/antonmashko/envconf/option"
+)
+
+var envconfFlatDefaultFields = [...]envconf.GenField{
+	{
+		Name:       "Field1",
+		FullName:   "Field1",
+		Path:       []string{"Field1"},
+		Type:       reflect.TypeOf((*bool)(nil)).Elem(),
+		Flag:       "-",
+		Env:        "-",
+		Default:    "true",
+		HasDefault: true,
+	},
+	{
+		Name:       "Field2",
+		FullName:   "Field2",
+		Path:       []string{"Field2"},
+		Type:       reflect.TypeOf((*int)(nil)).Elem(),
+		Flag:       "-",
+		Env:        "-",
+		Default:    "1",
+		HasDefault: true,
+	},
+	{
+		Name:       "Field3",
+		FullName:   "Field3",
+		Path:       []string{"Field3"},
+		Type:       reflect.TypeOf((*int8)(nil)).Elem(),
+		Flag:       "-",
+		Env:        "-",
+		Default:    "2",
+		HasDefault: true,
+	},
+	{
+		Name:       "Field4",
+		FullName:   "Field4",
+		Path:       []string{"Field4"},
+		Type:       reflect.TypeOf((*int16)(nil)).Elem(),
+		Flag:       "-",
+		Env:        "-",
+		Default:    "3",
+		HasDefault: true,
+	},
+	{
+		Name:       "Field5",
+		FullName:   "Field5",
+		Path:       []string{"Field5"},
+		Type:       reflect.TypeOf((*int32)(nil)).Elem(),
+		Flag:       "-",
+		Env:        "-",
+		Default:    "4",
+		HasDefault: true,
+	},
+	{
+		Name:       "Field6",
+		FullName:   "Field6",
+		Path:       []string{"Field6"},
+		Type:       reflect.TypeOf((*int64)(nil)).Elem(),
+		Flag:       "-",
+		Env:        "-",
+		Default:    "5",
+		HasDefault: true,
+	},
+	{
+		Name:       "Field7",
+		FullName:   "Field7",
+		Path:       []string{"Field7"},
+		Type:       reflect.TypeOf((*uint)(nil)).Elem(),
+		Flag:       "-",
+		Env:        "-",
+		Default:    "6",
+		HasDefault: true,
+	},
+	{
+		Name:       "Field8",
+		FullName:   "Field8",
+		Path:       []string{"Field8"},
+		Type:       reflect.TypeOf((*uint8)(nil)).Elem(),
+		Flag:       "-",
+		Env:        "-",
+		Default:    "7",
+		HasDefault: true,
+	},
+	{
+		Name:       "Field9",
+		FullName:   "Field9",
+		Path:       []string{"Field9"},
+		Type:       reflect.TypeOf((*uint16)(nil)).Elem(),
+		Flag:       "-",
+		Env:        "-",
+		Default:    "8",
+		HasDefault: true,
+	},
+	{
+		Name:       "Field10",
+		FullName:   "Field10",
+		Path:       []string{"Field10"},
+		Type:       reflect.TypeOf((*uint32)(nil)).Elem(),
+		Flag:       "-",
+		Env:        "-",
+		Default:    "9",
+		HasDefault: true,
+	},
+	{
+		Name:       "Field11",
+		FullName:   "Field11",
+		Path:       []string{"Field11"},
+		Type:       reflect.TypeOf((*uint64)(nil)).Elem(),
+		Flag:       "-",
+		Env:        "-",
+		Default:    "10",
+		HasDefault: true,
+	},
+	{
+		Name:       "Field12",
+		FullName:   "Field12",
+		Path:       []string{"Field12"},
+		Type:       reflect.TypeOf((*float32)(nil)).Elem(),
+		Flag:       "-",
+		Env:        "-",
+		Default:    "11",
+		HasDefault: true,
+	},
+	{
+		Name:       "Field13",
+		FullName:   "Field13",
+		Path:       []string{"Field13"},
+		Type:       reflect.TypeOf((*float64)(nil)).Elem(),
+		Flag:       "-",
+		Env:        "-",
+		Default:    "12",
+		HasDefault: true,
+	},
+	{
+		Name:       "Field14",
+		FullName:   "Field14",
+		Path:       []string{"Field14"},
+		Type:       reflect.TypeOf((*string)(nil)).Elem(),
+		Flag:       "-",
+		Env:        "-",
+		Default:    "13",
+		HasDefault: true,
+	},
+}
+
+// ParseFlatDefault defines fields of data from flags, environment variables, external source and default values.
+// It behaves like envconf.Parse without reflection over FlatDefault
+func ParseFlatDefault(data *FlatDefault, opts ...option.ClientOption) error {
+	return ParseFlatDefaultWith(envconf.New(), data, opts...)
+}
+
+// ParseFlatDefaultWith defines fields of data with ec. See ParseFlatDefault
+func ParseFlatDefaultWith(ec *envconf.EnvConf, data *FlatDefault, opts ...option.ClientOption) error {
+	if data == nil {
+		return envconf.ErrNilData
+	}
+	fields := &envconfFlatDefaultFields
+	g := ec.Generated(opts...)
+	for i := range fields {
+		if err := g.Init(&fields[i]); err != nil {
+			return err
+		}
+	}
+	cfg := *data
+	var shadow FlatDefault
+	var err error
+	if g.StrictPriority() {
+		err = g.Begin(&shadow)
+	} else {
+		err = g.Begin(&cfg)
+	}
+	if err != nil {
+		return err
+	}
+	// Field1
+	if s, cs, err := g.Lookup(&fields[0]); err != nil {
+		return err
+	} else if cs == option.ExternalSource {
+		if g.StrictPriority() {
+			cfg.Field1 = shadow.Field1
+		}
+	} else if cs != option.NoConfigValue {
+		v, err := strconv.ParseBool(strings.Trim(s, " "))
+		if err != nil {
+			return g.ConversionError(&fields[0], s, cs, err)
+		}
+		cfg.Field1 = v
+		g.Defined(&fields[0], v, cs)
+	}
+	// Field2
+	if s, cs, err := g.Lookup(&fields[1]); err != nil {
+		return err
+	} else if cs == option.ExternalSource {
+		if g.StrictPriority() {
+			cfg.Field2 = shadow.Field2
+		}
+	} else if cs != option.NoConfigValue {
+		v, err := strconv.ParseInt(strings.Trim(s, " "), 0, strconv.IntSize)
+		if err != nil {
+			return g.ConversionError(&fields[1], s, cs, err)
+		}
+		cfg.Field2 = int(v)
+		g.Defined(&fields[1], v, cs)
+	}
+	// Field3
+	if s, cs, err := g.Lookup(&fields[2]); err != nil {
+		return err
+	} else if cs == option.ExternalSource {
+		if g.StrictPriority() {
+			cfg.Field3 = shadow.Field3
+		}
+	} else if cs != option.NoConfigValue {
+		v, err := strconv.ParseInt(strings.Trim(s, " "), 0, 8)
+		if err != nil {
+			return g.ConversionError(&fields[2], s, cs, err)
+		}
+		cfg.Field3 = int8(v)
+		g.Defined(&fields[2], v, cs)
+	}
+	// Field4
+	if s, cs, err := g.Lookup(&fields[3]); err != nil {
+		return err
+	} else if cs == option.ExternalSource {
+		if g.StrictPriority() {
+			cfg.Field4 = shadow.Field4
+		}
+	} else if cs != option.NoConfigValue {
+		v, err := strconv.ParseInt(strings.Trim(s, " "), 0, 16)
+		if err != nil {
+			return g.ConversionError(&fields[3], s, cs, err)
+		}
+		cfg.Field4 = int16(v)
+		g.Defined(&fields[3], v, cs)
+	}
+	// Field5
+	if s, cs, err := g.Lookup(&fields[4]); err != nil {
+		return err
+	} else if cs == option.ExternalSource {
+		if g.StrictPriority() {
+			cfg.Field5 = shadow.Field5
+		}
+	} else if cs != option.NoConfigValue {
+		v, err := strconv.ParseInt(strings.Trim(s, " "), 0, 32)
+		if err != nil {
+			return g.ConversionError(&fields[4], s, cs, err)
+		}
+		cfg.Field5 = int32(v)
+		g.Defined(&fields[4], v, cs)
+	}
+	// Field6
+	if s, cs, err := g.Lookup(&fields[5]); err != nil {
+		return err
+	} else if cs == option.ExternalSource {
+		if g.StrictPriority() {
+			cfg.Field6 = shadow.Field6
+		}
+	} else if cs != option.NoConfigValue {
+		v, err := strconv.ParseInt(strings.Trim(s, " "), 0, 64)
+		if err != nil {
+			return g.ConversionError(&fields[5], s, cs, err)
+		}
+		cfg.Field6 = v
+		g.Defined(&fields[5], v, cs)
+	}
+	// Field7
+	if s, cs, err := g.Lookup(&fields[6]); err != nil {
+		return err
+	} else if cs == option.ExternalSource {
+		if g.StrictPriority() {
+			cfg.Field7 = shadow.Field7
+		}
+	} else if cs != option.NoConfigValue {
+		v, err := strconv.ParseUint(strings.Trim(s, " "), 0, strconv.IntSize)
+		if err != nil {
+			return g.ConversionError(&fields[6], s, cs, err)
+		}
+		cfg.Field7 = uint(v)
+		g.Defined(&fields[6], v, cs)
+	}
+	// Field8
+	if s, cs, err := g.Lookup(&fields[7]); err != nil {
+		return err
+	} else if cs == option.ExternalSource {
+		if g.StrictPriority() {
+			cfg.Field8 = shadow.Field8
+		}
+	} else if cs != option.NoConfigValue {
+		v, err := strconv.ParseUint(strings.Trim(s, " "), 0, 8)
+		if err != nil {
+			return g.ConversionError(&fields[7], s, cs, err)
+		}
+		cfg.Field8 = uint8(v)
+		g.Defined(&fields[7], v, cs)
+	}
+	// Field9
+	if s, cs, err := g.Lookup(&fields[8]); err != nil {
+		return err
+	} else if cs == option.ExternalSource {
+		if g.StrictPriority() {
+			cfg.Field9 = shadow.Field9
+		}
+	} else if cs != option.NoConfigValue {
+		v, err := strconv.ParseUint(strings.Trim(s, " "), 0, 16)
+		if err != nil {
+			return g.ConversionError(&fields[8], s, cs, err)
+		}
+		cfg.Field9 = uint16(v)
+		g.Defined(&fields[8], v, cs)
+	}
+	// Field10
+	if s, cs, err := g.Lookup(&fields[9]); err != nil {
+		return err
+	} else if cs == option.ExternalSource {
+		if g.StrictPriority() {
+			cfg.Field10 = shadow.Field10
+		}
+	} else if cs != option.NoConfigValue {
+		v, err := strconv.ParseUint(strings.Trim(s, " "), 0, 32)
+		if err != nil {
+			return g.ConversionError(&fields[9], s, cs, err)
+		}
+		cfg.Field10 = uint32(v)
+		g.Defined(&fields[9], v, cs)
+	}
+	// Field11
+	if s, cs, err := g.Lookup(&fields[10]); err != nil {
+		return err
+	} else if cs == option.ExternalSource {
+		if g.StrictPriority() {
+			cfg.Field11 = shadow.Field11
+		}
+	} else if cs != option.NoConfigValue {
+		v, err := strconv.ParseUint(strings.Trim(s, " "), 0, 64)
+		if err != nil {
+			return g.ConversionError(&fields[10], s, cs, err)
+		}
+		cfg.Field11 = v
+		g.Defined(&fields[10], v, cs)
+	}
+	// Field12
+	if s, cs, err := g.Lookup(&fields[11]); err != nil {
+		return err
+	} else if cs == option.ExternalSource {
+		if g.StrictPriority() {
+			cfg.Field12 = shadow.Field12
+		}
+	} else if cs != option.NoConfigValue {
+		v, err := strconv.ParseFloat(strings.Trim(s, " "), 32)
+		if err != nil {
+			return g.ConversionError(&fields[11], s, cs, err)
+		}
+		cfg.Field12 = float32(v)
+		g.Defined(&fields[11], v, cs)
+	}
+	// Field13
+	if s, cs, err := g.Lookup(&fields[12]); err != nil {
+		return err
+	} else if cs == option.ExternalSource {
+		if g.StrictPriority() {
+			cfg.Field13 = shadow.Field13
+		}
+	} else if cs != option.NoConfigValue {
+		v, err := strconv.ParseFloat(strings.Trim(s, " "), 64)
+		if err != nil {
+			return g.ConversionError(&fields[12], s, cs, err)
+		}
+		cfg.Field13 = v
+		g.Defined(&fields[12], v, cs)
+	}
+	// Field14
+	if s, cs, err := g.Lookup(&fields[13]); err != nil {
+		return err
+	} else if cs == option.ExternalSource {
+		if g.StrictPriority() {
+			cfg.Field14 = shadow.Field14
+		}
+	} else if cs != option.NoConfigValue {
+		cfg.Field14 = s
+		g.Defined(&fields[13], s, cs)
+	}
+	if err = g.End(); err != nil {
+		return err
+	}
+	*data = cfg
+	return nil
+}
+
+var envconfFlatEnvFields = [...]envconf.GenField{
+	{
+		Name:     "Field1",
+		FullName: "Field1",
+		Path:     []string{"Field1"},
+		Type:     reflect.TypeOf((*bool)(nil)).Elem(),
+		Flag:     "-",
+		Env:      "TEST_FIELD_1",
+	},
+	{
+		Name:     "Field2",
+		FullName: "Field2",
+		Path:     []string{"Field2"},
+		Type:     reflect.TypeOf((*int)(nil)).Elem(),
+		Flag:     "-",
+		Env:      "TEST_FIELD_2",
+	},
+	{
+		Name:     "Field3",
+		FullName: "Field3",
+		Path:     []string{"Field3"},
+		Type:     reflect.TypeOf((*int8)(nil)).Elem(),
+		Flag:     "-",
+		Env:      "TEST_FIELD_3",
+	},
+	{
+		Name:     "Field4",
+		FullName: "Field4",
+		Path:     []string{"Field4"},
+		Type:     reflect.TypeOf((*int16)(nil)).Elem(),
+		Flag:     "-",
+		Env:      "TEST_FIELD_4",
+	},
+	{
+		Name:     "Field5",
+		FullName: "Field5",
+		Path:     []string{"Field5"},
+		Type:     reflect.TypeOf((*int32)(nil)).Elem(),
+		Flag:     "-",
+		Env:      "TEST_FIELD_5",
+	},
+	{
+		Name:     "Field6",
+		FullName: "Field6",
+		Path:     []string{"Field6"},
+		Type:     reflect.TypeOf((*int64)(nil)).Elem(),
+		Flag:     "-",
+		Env:      "TEST_FIELD_6",
+	},
+	{
+		Name:     "Field7",
+		FullName: "Field7",
+		Path:     []string{"Field7"},
+		Type:     reflect.TypeOf((*uint)(nil)).Elem(),
+		Flag:     "-",
+		Env:      "TEST_FIELD_7",
+	},
+	{
+		Name:     "Field8",
+		FullName: "Field8",
+		Path:     []string{"Field8"},
+		Type:     reflect.TypeOf((*uint8)(nil)).Elem(),
+		Flag:     "-",
+		Env:      "TEST_FIELD_8",
+	},
+	{
+		Name:     "Field9",
+		FullName: "Field9",
+		Path:     []string{"Field9"},
+		Type:     reflect.TypeOf((*uint16)(nil)).Elem(),
+		Flag:     "-",
+		Env:      "TEST_FIELD_9",
+	},
+	{
+		Name:     "Field10",
+		FullName: "Field10",
+		Path:     []string{"Field10"},
+		Type:     reflect.TypeOf((*uint32)(nil)).Elem(),
+		Flag:     "-",
+		Env:      "TEST_FIELD_10",
+	},
+	{
+		Name:     "Field11",
+		FullName: "Field11",
+		Path:     []string{"Field11"},
+		Type:     reflect.TypeOf((*uint64)(nil)).Elem(),
+		Flag:     "-",
+		Env:      "TEST_FIELD_11",
+	},
+	{
+		Name:     "Field12",
+		FullName: "Field12",
+		Path:     []string{"Field12"},
+		Type:     reflect.TypeOf((*float32)(nil)).Elem(),
+		Flag:     "-",
+		Env:      "TEST_FIELD_12",
+	},
+	{
+		Name:     "Field13",
+		FullName: "Field13",
+		Path:     []string{"Field13"},
+		Type:     reflect.TypeOf((*float64)(nil)).Elem(),
+		Flag:     "-",
+		Env:      "TEST_FIELD_13",
+	},
+	{
+		Name:     "Field14",
+		FullName: "Field14",
+		Path:     []string{"Field14"},
+		Type:     reflect.TypeOf((*string)(nil)).Elem(),
+		Flag:     "-",
+		Env:      "TEST_FIELD_14",
+	},
+}
+
+// ParseFlatEnv defines fields of data from flags, environment variables, external source and default values.
+// It behaves like envconf.Parse without reflection over FlatEnv
+func ParseFlatEnv(data *FlatEnv, opts ...option.ClientOption) error {
+	return ParseFlatEnvWith(envconf.New(), data, opts...)
+}
+
+// ParseFlatEnvWith defines fields of data with ec. See ParseFlatEnv
+func ParseFlatEnvWith(ec *envconf.EnvConf, data *FlatEnv, opts ...option.ClientOption) error {
+	if data == nil {
+		return envconf.ErrNilData
+	}
+	fields := &envconfFlatEnvFields
+	g := ec.Generated(opts...)
+	for i := range fields {
+		if err := g.Init(&fields[i]); err != nil {
+			return err
+		}
+	}
+	cfg := *data
+	var shadow FlatEnv
+	var err error
+	if g.StrictPriority() {
+		err = g.Begin(&shadow)
+	} else {
+		err = g.Begin(&cfg)
+	}
+	if err != nil {
+		return err
+	}
+	// Field1
+	if s, cs, err := g.Lookup(&fields[0]); err != nil {
+		return err
+	} else if cs == option.ExternalSource {
+		if g.StrictPriority() {
+			cfg.Field1 = shadow.Field1
+		}
+	} else if cs != option.NoConfigValue {
+		v, err := strconv.ParseBool(strings.Trim(s, " "))
+		if err != nil {
+			return g.ConversionError(&fields[0], s, cs, err)
+		}
+		cfg.Field1 = v
+		g.Defined(&fields[0], v, cs)
+	}
+	// Field2
+	if s, cs, err := g.Lookup(&fields[1]); err != nil {
+		return err
+	} else if cs == option.ExternalSource {
+		if g.StrictPriority() {
+			cfg.Field2 = shadow.Field2
+		}
+	} else if cs != option.NoConfigValue {
+		v, err := strconv.ParseInt(strings.Trim(s, " "), 0, strconv.IntSize)
+		if err != nil {
+			return g.ConversionError(&fields[1], s, cs, err)
+		}
+		cfg.Field2 = int(v)
+		g.Defined(&fields[1], v, cs)
+	}
+	// Field3
+	if s, cs, err := g.Lookup(&fields[2]); err != nil {
+		return err
+	} else if cs == option.ExternalSource {
+		if g.StrictPriority() {
+			cfg.Field3 = shadow.Field3
+		}
+	} else if cs != option.NoConfigValue {
+		v, err := strconv.ParseInt(strings.Trim(s, " "), 0, 8)
+		if err != nil {
+			return g.ConversionError(&fields[2], s, cs, err)
+		}
+		cfg.Field3 = int8(v)
+		g.Defined(&fields[2], v, cs)
+	}
+	// Field4
+	if s, cs, err := g.Lookup(&fields[3]); err != nil {
+		return err
+	} else if cs == option.ExternalSource {
+		if g.StrictPriority() {
+			cfg.Field4 = shadow.Field4
+		}
+	} else if cs != option.NoConfigValue {
+		v, err := strconv.ParseInt(strings.Trim(s, " "), 0, 16)
+		if err != nil {
+			return g.ConversionError(&fields[3], s, cs, err)
+		}
+		cfg.Field4 = int16(v)
+		g.Defined(&fields[3], v, cs)
+	}
+	// Field5
+	if s, cs, err := g.Lookup(&fields[4]); err != nil {
+		return err
+	} else if cs == option.ExternalSource {
+		if g.StrictPriority() {
+			cfg.Field5 = shadow.Field5
+		}
+	} else if cs != option.NoConfigValue {
+		v, err := strconv.ParseInt(strings.Trim(s, " "), 0, 32)
+		if err != nil {
+			return g.ConversionError(&fields[4], s, cs, err)
+		}
+		cfg.Field5 = int32(v)
+		g.Defined(&fields[4], v, cs)
+	}
+	// Field6
+	if s, cs, err := g.Lookup(&fields[5]); err != nil {
+		return err
+	} else if cs == option.ExternalSource {
+		if g.StrictPriority() {
+			cfg.Field6 = shadow.Field6
+		}
+	} else if cs != option.NoConfigValue {
+		v, err := strconv.ParseInt(strings.Trim(s, " "), 0, 64)
+		if err != nil {
+			return g.ConversionError(&fields[5], s, cs, err)
+		}
+		cfg.Field6 = v
+		g.Defined(&fields[5], v, cs)
+	}
+	// Field7
+	if s, cs, err := g.Lookup(&fields[6]); err != nil {
+		return err
+	} else if cs == option.ExternalSource {
+		if g.StrictPriority() {
+			cfg.Field7 = shadow.Field7
+		}
+	} else if cs != option.NoConfigValue {
+		v, err := strconv.ParseUint(strings.Trim(s, " "), 0, strconv.IntSize)
+		if err != nil {
+			return g.ConversionError(&fields[6], s, cs, err)
+		}
+		cfg.Field7 = uint(v)
+		g.Defined(&fields[6], v, cs)
+	}
+	// Field8
+	if s, cs, err := g.Lookup(&fields[7]); err != nil {
+		return err
+	} else if cs == option.ExternalSource {
+		if g.StrictPriority() {
+			cfg.Field8 = shadow.Field8
+		}
+	} else if cs != option.NoConfigValue {
+		v, err := strconv.ParseUint(strings.Trim(s, " "), 0, 8)
+		if err != nil {
+			return g.ConversionError(&fields[7], s, cs, err)
+		}
+		cfg.Field8 = uint8(v)
+		g.Defined(&fields[7], v, cs)
+	}
+	// Field9
+	if s, cs, err := g.Lookup(&fields[8]); err != nil {
+		return err
+	} else if cs == option.ExternalSource {
+		if g.StrictPriority() {
+			cfg.Field9 = shadow.Field9
+		}
+	} else if cs != option.NoConfigValue {
+		v, err := strconv.ParseUint(strings.Trim(s, " "), 0, 16)
+		if err != nil {
+			return g.ConversionError(&fields[8], s, cs, err)
+		}
+		cfg.Field9 = uint16(v)
+		g.Defined(&fields[8], v, cs)
+	}
+	// Field10
+	if s, cs, err := g.Lookup(&fields[9]); err != nil {
+		return err
+	} else if cs == option.ExternalSource {
+		if g.StrictPriority() {
+			cfg.Field10 = shadow.Field10
+		}
+	} else if cs != option.NoConfigValue {
+		v, err := strconv.ParseUint(strings.Trim(s, " "), 0, 32)
+		if err != nil {
+			return g.ConversionError(&fields[9], s, cs, err)
+		}
+		cfg.Field10 = uint32(v)
+		g.Defined(&fields[9], v, cs)
+	}
+	// Field11
+	if s, cs, err := g.Lookup(&fields[10]); err != nil {
+		return err
+	} else if cs == option.ExternalSource {
+		if g.StrictPriority() {
+			cfg.Field11 = shadow.Field11
+		}
+	} else if cs != option.NoConfigValue {
+		v, err := strconv.ParseUint(strings.Trim(s, " "), 0, 64)
+		if err != nil {
+			return g.ConversionError(&fields[10], s, cs, err)
+		}
+		cfg.Field11 = v
+		g.Defined(&fields[10], v, cs)
+	}
+	// Field12
+	if s, cs, err := g.Lookup(&fields[11]); err != nil {
+		return err
+	} else if cs == option.ExternalSource {
+		if g.StrictPriority() {
+			cfg.Field12 = shadow.Field12
+		}
+	} else if cs != option.NoConfigValue {
+		v, err := strconv.ParseFloat(strings.Trim(s, " "), 32)
+		if err != nil {
+			return g.ConversionError(&fields[11], s, cs, err)
+		}
+		cfg.Field12 = float32(v)
+		g.Defined(&fields[11], v, cs)
+	}
+	// Field13
+	if s, cs, err := g.Lookup(&fields[12]); err != nil {
+		return err
+	} else if cs == option.ExternalSource {
+		if g.StrictPriority() {
+			cfg.Field13 = shadow.Field13
+		}
+	} else if cs != option.NoConfigValue {
+		v, err := strconv.ParseFloat(strings.Trim(s, " "), 64)
+		if err != nil {
+			return g.ConversionError(&fields[12], s, cs, err)
+		}
+		cfg.Field13 = v
+		g.Defined(&fields[12], v, cs)
+	}
+	// Field14
+	if s, cs, err := g.Lookup(&fields[13]); err != nil {
+		return err
+	} else if cs == option.ExternalSource {
+		if g.StrictPriority() {
+			cfg.Field14 = shadow.Field14
+		}
+	} else if cs != option.NoConfigValue {
+		cfg.Field14 = s
+		g.Defined(&fields[13], s, cs)
+	}
+	if err = g.End(); err != nil {
+		return err
+	}
+	*data = cfg
+	return nil
+}
+
+var envconfNestedFields = [...]envconf.GenField{
+	{
+		Name:       "Field1",
+		FullName:   "Inner1.Inner13.Inner131.Field1",
+		Path:       []string{"Inner1", "Inner13", "Inner131", "Field1"},
+		Parents:    []string{"Inner1", "Inner13", "Inner131"},
+		Type:       reflect.TypeOf((*int)(nil)).Elem(),
+		Flag:       "-",
+		Env:        "-",
+		Default:    "123",
+		HasDefault: true,
+	},
+}
+
+// ParseNested defines fields of data from flags, environment variables, external source and default values.
+// It behaves like envconf.Parse without reflection over Nested
+func ParseNested(data *Nested, opts ...option.ClientOption) error {
+	return ParseNestedWith(envconf.New(), data, opts...)
+}
+
+// ParseNestedWith defines fields of data with ec. See ParseNested
+func ParseNestedWith(ec *envconf.EnvConf, data *Nested, opts ...option.ClientOption) error {
+	if data == nil {
+		return envconf.ErrNilData
+	}
+	fields := &envconfNestedFields
+	g := ec.Generated(opts...)
+	for i := range fields {
+		if err := g.Init(&fields[i]); err != nil {
+			return err
+		}
+	}
+	cfg := *data
+	var shadow Nested
+	var err error
+	if g.StrictPriority() {
+		err = g.Begin(&shadow)
+	} else {
+		err = g.Begin(&cfg)
+	}
+	if err != nil {
+		return err
+	}
+	// Inner1.Inner13.Inner131.Field1
+	if s, cs, err := g.Lookup(&fields[0]); err != nil {
+		return err
+	} else if cs == option.ExternalSource {
+		if g.StrictPriority() {
+			cfg.Inner1.Inner13.Inner131.Field1 = shadow.Inner1.Inner13.Inner131.Field1
+		}
+	} else if cs != option.NoConfigValue {
+		v, err := strconv.ParseInt(strings.Trim(s, " "), 0, strconv.IntSize)
+		if err != nil {
+			return g.ConversionError(&fields[0], s, cs, err)
+		}
+		cfg.Inner1.Inner13.Inner131.Field1 = int(v)
+		g.Defined(&fields[0], v, cs)
+	}
+	if err = g.End(); err != nil {
+		return err
+	}
+	*data = cfg
+	return nil
+}
+
+var envconfRenamedParentFields = [...]envconf.GenField{
+	{
+		Name:     "Field1",
+		FullName: "INNER.Field1",
+		Path:     []string{"Inner1", "Field1"},
+		Parents:  []string{"INNER"},
+		Type:     reflect.TypeOf((*string)(nil)).Elem(),
+		Flag:     "-",
+		Env:      "INNER_FIELD1",
+	},
+}
+
+// ParseRenamedParent defines fields of data from flags, environment variables, external source and default values.
+// It behaves like envconf.Parse without reflection over RenamedParent
+func ParseRenamedParent(data *RenamedParent, opts ...option.ClientOption) error {
+	return ParseRenamedParentWith(envconf.New(), data, opts...)
+}
+
+// ParseRenamedParentWith defines fields of data with ec. See ParseRenamedParent
+func ParseRenamedParentWith(ec *envconf.EnvConf, data *RenamedParent, opts ...option.ClientOption) error {
+	if data == nil {
+		return envconf.ErrNilData
+	}
+	fields := &envconfRenamedParentFields
+	g := ec.Generated(opts...)
+	for i := range fields {
+		if err := g.Init(&fields[i]); err != nil {
+			return err
+		}
+	}
+	cfg := *data
+	var shadow RenamedParent
+	var err error
+	if g.StrictPriority() {
+		err = g.Begin(&shadow)
+	} else {
+		err = g.Begin(&cfg)
+	}
+	if err != nil {
+		return err
+	}
+	// INNER.Field1
+	if s, cs, err := g.Lookup(&fields[0]); err != nil {
+		return err
+	} else if cs == option.ExternalSource {
+		if g.StrictPriority() {
+			cfg.Inner1.Field1 = shadow.Inner1.Field1
+		}
+	} else if cs != option.NoConfigValue {
+		cfg.Inner1.Field1 = s
+		g.Defined(&fields[0], s, cs)
+	}
+	if err = g.End(); err != nil {
+		return err
+	}
+	*data = cfg
+	return nil
+}
+
+var envconfRequiredValueFields = [...]envconf.GenField{
+	{
+		Name:     "Field1",
+		FullName: "Field1",
+		Path:     []string{"Field1"},
+		Type:     reflect.TypeOf((*string)(nil)).Elem(),
+		Flag:     "-",
+		Env:      "TEST_FIELD1",
+		Required: true,
+	},
+}
+
+// ParseRequiredValue defines fields of data from flags, environment variables, external source and default values.
+// It behaves like envconf.Parse without reflection over RequiredValue
+func ParseRequiredValue(data *RequiredValue, opts ...option.ClientOption) error {
+	return ParseRequiredValueWith(envconf.New(), data, opts...)
+}
+
+// ParseRequiredValueWith defines fields of data with ec. See ParseRequiredValue
+func ParseRequiredValueWith(ec *envconf.EnvConf, data *RequiredValue, opts ...option.ClientOption) error {
+	if data == nil {
+		return envconf.ErrNilData
+	}
+	fields := &envconfRequiredValueFields
+	g := ec.Generated(opts...)
+	for i := range fields {
+		if err := g.Init(&fields[i]); err != nil {
+			return err
+		}
+	}
+	cfg := *data
+	var shadow RequiredValue
+	var err error
+	if g.StrictPriority() {
+		err = g.Begin(&shadow)
+	} else {
+		err = g.Begin(&cfg)
+	}
+	if err != nil {
+		return err
+	}
+	// Field1
+	if s, cs, err := g.Lookup(&fields[0]); err != nil {
+		return err
+	} else if cs == option.ExternalSource {
+		if g.StrictPriority() {
+			cfg.Field1 = shadow.Field1
+		}
+	} else if cs != option.NoConfigValue {
+		cfg.Field1 = s
+		g.Defined(&fields[0], s, cs)
+	}
+	if err = g.End(); err != nil {
+		return err
+	}
+	*data = cfg
+	return nil
+}
+
+var envconfSharedEnvFields = [...]envconf.GenField{
+	{
+		Name:     "Field",
+		FullName: "Inner.Field",
+		Path:     []string{"Inner", "Field"},
+		Parents:  []string{"Inner"},
+		Type:     reflect.TypeOf((*string)(nil)).Elem(),
+		Flag:     "-",
+		Env:      "TEST_NAMES_SHARED_ENV",
+	},
+	{
+		Name:     "Field",
+		FullName: "Field",
+		Path:     []string{"Field"},
+		Type:     reflect.TypeOf((*string)(nil)).Elem(),
+		Flag:     "-",
+		Env:      "TEST_NAMES_SHARED_ENV",
+	},
+}
+
+// ParseSharedEnv defines fields of data from flags, environment variables, external source and default values.
+// It behaves like envconf.Parse without reflection over SharedEnv
+func ParseSharedEnv(data *SharedEnv, opts ...option.ClientOption) error {
+	return ParseSharedEnvWith(envconf.New(), data, opts...)
+}
+
+// ParseSharedEnvWith defines fields of data with ec. See ParseSharedEnv
+func ParseSharedEnvWith(ec *envconf.EnvConf, data *SharedEnv, opts ...option.ClientOption) error {
+	if data == nil {
+		return envconf.ErrNilData
+	}
+	fields := &envconfSharedEnvFields
+	g := ec.Generated(opts...)
+	for i := range fields {
+		if err := g.Init(&fields[i]); err != nil {
+			return err
+		}
+	}
+	cfg := *data
+	var shadow SharedEnv
+	var err error
+	if g.StrictPriority() {
+		err = g.Begin(&shadow)
+	} else {
+		err = g.Begin(&cfg)
+	}
+	if err != nil {
+		return err
+	}
+	// Inner.Field
+	if s, cs, err := g.Lookup(&fields[0]); err != nil {
+		return err
+	} else if cs == option.ExternalSource {
+		if g.StrictPriority() {
+			cfg.Inner.Field = shadow.Inner.Field
+		}
+	} else if cs != option.NoConfigValue {
+		cfg.Inner.Field = s
+		g.Defined(&fields[0], s, cs)
+	}
+	// Field
+	if s, cs, err := g.Lookup(&fields[1]); err != nil {
+		return err
+	} else if cs == option.ExternalSource {
+		if g.StrictPriority() {
+			cfg.Field = shadow.Field
+		}
+	} else if cs != option.NoConfigValue {
+		cfg.Field = s
+		g.Defined(&fields[1], s, cs)
+	}
+	if err = g.End(); err != nil {
+		return err
+	}
+	*data = cfg
+	return nil
+}
+
+var envconfPriorityFieldFields = [...]envconf.GenField{
+	{
+		Name:     "Field1",
+		FullName: "Field1",
+		Path:     []string{"Field1"},
+		Type:     reflect.TypeOf((*string)(nil)).Elem(),
+		Flag:     "-",
+		Env:      "TEST_PRIORITY_TAG_FIELD1",
+		Priority: []option.ConfigSource{option.ExternalSource, option.EnvVariable, option.FlagVariable, option.DefaultValue},
+	},
+	{
+		Name:     "Field2",
+		FullName: "Field2",
+		Path:     []string{"Field2"},
+		Type:     reflect.TypeOf((*string)(nil)).Elem(),
+		Flag:     "-",
+		Env:      "TEST_PRIORITY_TAG_FIELD2",
+	},
+}
+
+// ParsePriorityField defines fields of data from flags, environment variables, external source and default values.
+// It behaves like envconf.Parse without reflection over PriorityField
+func ParsePriorityField(data *PriorityField, opts ...option.ClientOption) error {
+	return ParsePriorityFieldWith(envconf.New(), data, opts...)
+}
+
+// ParsePriorityFieldWith defines fields of data with ec. See ParsePriorityField
+func ParsePriorityFieldWith(ec *envconf.EnvConf, data *PriorityField, opts ...option.ClientOption) error {
+	if data == nil {
+		return envconf.ErrNilData
+	}
+	fields := &envconfPriorityFieldFields
+	g := ec.Generated(opts...)
+	for i := range fields {
+		if err := g.Init(&fields[i]); err != nil {
+			return err
+		}
+	}
+	cfg := *data
+	var shadow PriorityField
+	var err error
+	if g.StrictPriority() {
+		err = g.Begin(&shadow)
+	} else {
+		err = g.Begin(&cfg)
+	}
+	if err != nil {
+		return err
+	}
+	// Field1
+	if s, cs, err := g.Lookup(&fields[0]); err != nil {
+		return err
+	} else if cs == option.ExternalSource {
+		if g.StrictPriority() {
+			cfg.Field1 = shadow.Field1
+		}
+	} else if cs != option.NoConfigValue {
+		cfg.Field1 = s
+		g.Defined(&fields[0], s, cs)
+	}
+	// Field2
+	if s, cs, err := g.Lookup(&fields[1]); err != nil {
+		return err
+	} else if cs == option.ExternalSource {
+		if g.StrictPriority() {
+			cfg.Field2 = shadow.Field2
+		}
+	} else if cs != option.NoConfigValue {
+		cfg.Field2 = s
+		g.Defined(&fields[1], s, cs)
+	}
+	if err = g.End(); err != nil {
+		return err
+	}
+	*data = cfg
+	return nil
+}
+
+var envconfPriorityInheritedFields = [...]envconf.GenField{
+	{
+		Name:       "Field1",
+		FullName:   "Inner.Field1",
+		Path:       []string{"Inner", "Field1"},
+		Parents:    []string{"Inner"},
+		Type:       reflect.TypeOf((*string)(nil)).Elem(),
+		Flag:       "-",
+		Env:        "TEST_PRIORITY_TAG_INNER_FIELD1",
+		Default:    "from-default",
+		HasDefault: true,
+		Priority:   []option.ConfigSource{option.DefaultValue, option.EnvVariable},
+	},
+	{
+		Name:       "Field2",
+		FullName:   "Inner.Field2",
+		Path:       []string{"Inner", "Field2"},
+		Parents:    []string{"Inner"},
+		Type:       reflect.TypeOf((*string)(nil)).Elem(),
+		Flag:       "-",
+		Env:        "TEST_PRIORITY_TAG_INNER_FIELD2",
+		Default:    "from-default",
+		HasDefault: true,
+		Priority:   []option.ConfigSource{option.EnvVariable},
+	},
+}
+
+// ParsePriorityInherited defines fields of data from flags, environment variables, external source and default values.
+// It behaves like envconf.Parse without reflection over PriorityInherited
+func ParsePriorityInherited(data *PriorityInherited, opts ...option.ClientOption) error {
+	return ParsePriorityInheritedWith(envconf.New(), data, opts...)
+}
+
+// ParsePriorityInheritedWith defines fields of data with ec. See ParsePriorityInherited
+func ParsePriorityInheritedWith(ec *envconf.EnvConf, data *PriorityInherited, opts ...option.ClientOption) error {
+	if data == nil {
+		return envconf.ErrNilData
+	}
+	fields := &envconfPriorityInheritedFields
+	g := ec.Generated(opts...)
+	for i := range fields {
+		if err := g.Init(&fields[i]); err != nil {
+			return err
+		}
+	}
+	cfg := *data
+	var shadow PriorityInherited
+	var err error
+	if g.StrictPriority() {
+		err = g.Begin(&shadow)
+	} else {
+		err = g.Begin(&cfg)
+	}
+	if err != nil {
+		return err
+	}
+	// Inner.Field1
+	if s, cs, err := g.Lookup(&fields[0]); err != nil {
+		return err
+	} else if cs == option.ExternalSource {
+		if g.StrictPriority() {
+			cfg.Inner.Field1 = shadow.Inner.Field1
+		}
+	} else if cs != option.NoConfigValue {
+		cfg.Inner.Field1 = s
+		g.Defined(&fields[0], s, cs)
+	}
+	// Inner.Field2
+	if s, cs, err := g.Lookup(&fields[1]); err != nil {
+		return err
+	} else if cs == option.ExternalSource {
+		if g.StrictPriority() {
+			cfg.Inner.Field2 = shadow.Inner.Field2
+		}
+	} else if cs != option.NoConfigValue {
+		cfg.Inner.Field2 = s
+		g.Defined(&fields[1], s, cs)
+	}
+	if err = g.End(); err != nil {
+		return err
+	}
+	*data = cfg
+	return nil
+}
+
+var envconfPriorityWithSourcesFields = [...]envconf.GenField{
+	{
+		Name:       "Field",
+		FullName:   "Field",
+		Path:       []string{"Field"},
+		Type:       reflect.TypeOf((*string)(nil)).Elem(),
+		Flag:       "-",
+		Env:        "-",
+		Default:    "value",
+		HasDefault: true,
+		Sources:    []option.ConfigSource{option.EnvVariable, option.DefaultValue},
+		Priority:   []option.ConfigSource{option.ExternalSource, option.EnvVariable, option.DefaultValue},
+	},
+}
+
+// ParsePriorityWithSources defines fields of data from flags, environment variables, external source and default values.
+// It behaves like envconf.Parse without reflection over PriorityWithSources
+func ParsePriorityWithSources(data *PriorityWithSources, opts ...option.ClientOption) error {
+	return ParsePriorityWithSourcesWith(envconf.New(), data, opts...)
+}
+
+// ParsePriorityWithSourcesWith defines fields of data with ec. See ParsePriorityWithSources
+func ParsePriorityWithSourcesWith(ec *envconf.EnvConf, data *PriorityWithSources, opts ...option.ClientOption) error {
+	if data == nil {
+		return envconf.ErrNilData
+	}
+	fields := &envconfPriorityWithSourcesFields
+	g := ec.Generated(opts...)
+	for i := range fields {
+		if err := g.Init(&fields[i]); err != nil {
+			return err
+		}
+	}
+	cfg := *data
+	var shadow PriorityWithSources
+	var err error
+	if g.StrictPriority() {
+		err = g.Begin(&shadow)
+	} else {
+		err = g.Begin(&cfg)
+	}
+	if err != nil {
+		return err
+	}
+	// Field
+	if s, cs, err := g.Lookup(&fields[0]); err != nil {
+		return err
+	} else if cs == option.ExternalSource {
+		if g.StrictPriority() {
+			cfg.Field = shadow.Field
+		}
+	} else if cs != option.NoConfigValue {
+		cfg.Field = s
+		g.Defined(&fields[0], s, cs)
+	}
+	if err = g.End(); err != nil {
+		return err
+	}
+	*data = cfg
+	return nil
+}
+
+var envconfAllowedSourceFields = [...]envconf.GenField{
+	{
+		Name:     "Field",
+		FullName: "Field",
+		Path:     []string{"Field"},
+		Type:     reflect.TypeOf((*string)(nil)).Elem(),
+		Flag:     "-",
+		Env:      "TEST_SOURCES_ALLOWED",
+		Sources:  []option.ConfigSource{option.EnvVariable, option.ExternalSource},
+	},
+}
+
+// ParseAllowedSource defines fields of data from flags, environment variables, external source and default values.
+// It behaves like envconf.Parse without reflection over AllowedSource
+func ParseAllowedSource(data *AllowedSource, opts ...option.ClientOption) error {
+	return ParseAllowedSourceWith(envconf.New(), data, opts...)
+}
+
+// ParseAllowedSourceWith defines fields of data with ec. See ParseAllowedSource
+func ParseAllowedSourceWith(ec *envconf.EnvConf, data *AllowedSource, opts ...option.ClientOption) error {
+	if data == nil {
+		return envconf.ErrNilData
+	}
+	fields := &envconfAllowedSourceFields
+	g := ec.Generated(opts...)
+	for i := range fields {
+		if err := g.Init(&fields[i]); err != nil {
+			return err
+		}
+	}
+	cfg := *data
+	var shadow AllowedSource
+	var err error
+	if g.StrictPriority() {
+		err = g.Begin(&shadow)
+	} else {
+		err = g.Begin(&cfg)
+	}
+	if err != nil {
+		return err
+	}
+	// Field
+	if s, cs, err := g.Lookup(&fields[0]); err != nil {
+		return err
+	} else if cs == option.ExternalSource {
+		if g.StrictPriority() {
+			cfg.Field = shadow.Field
+		}
+	} else if cs != option.NoConfigValue {
+		cfg.Field = s
+		g.Defined(&fields[0], s, cs)
+	}
+	if err = g.End(); err != nil {
+		return err
+	}
+	*data = cfg
+	return nil
+}
+
+var envconfDisallowedSourceFields = [...]envconf.GenField{
+	{
+		Name:       "Field",
+		FullName:   "Field",
+		Path:       []string{"Field"},
+		Type:       reflect.TypeOf((*string)(nil)).Elem(),
+		Flag:       "-",
+		Env:        "-",
+		Default:    "value",
+		HasDefault: true,
+		Sources:    []option.ConfigSource{option.EnvVariable, option.DefaultValue},
+	},
+}
+
+// ParseDisallowedSource defines fields of data from flags, environment variables, external source and default values.
+// It behaves like envconf.Parse without reflection over DisallowedSource
+func ParseDisallowedSource(data *DisallowedSource, opts ...option.ClientOption) error {
+	return ParseDisallowedSourceWith(envconf.New(), data, opts...)
+}
+
+// ParseDisallowedSourceWith defines fields of data with ec. See ParseDisallowedSource
+func ParseDisallowedSourceWith(ec *envconf.EnvConf, data *DisallowedSource, opts ...option.ClientOption) error {
+	if data == nil {
+		return envconf.ErrNilData
+	}
+	fields := &envconfDisallowedSourceFields
+	g := ec.Generated(opts...)
+	for i := range fields {
+		if err := g.Init(&fields[i]); err != nil {
+			return err
+		}
+	}
+	cfg := *data
+	var shadow DisallowedSource
+	var err error
+	if g.StrictPriority() {
+		err = g.Begin(&shadow)
+	} else {
+		err = g.Begin(&cfg)
+	}
+	if err != nil {
+		return err
+	}
+	// Field
+	if s, cs, err := g.Lookup(&fields[0]); err != nil {
+		return err
+	} else if cs == option.ExternalSource {
+		if g.StrictPriority() {
+			cfg.Field = shadow.Field
+		}
+	} else if cs != option.NoConfigValue {
+		cfg.Field = s
+		g.Defined(&fields[0], s, cs)
+	}
+	if err = g.End(); err != nil {
+		return err
+	}
+	*data = cfg
+	return nil
+}
+
+var envconfSliceFields = [...]envconf.GenField{
+	{
+		Name:     "Field",
+		FullName: "Field",
+		Path:     []string{"Field"},
+		Type:     reflect.TypeOf((*[]int)(nil)).Elem(),
+		Flag:     "-",
+		Env:      "TEST_PARSE_SLICE_OK",
+		Slice:    true,
+	},
+}
+
+// ParseSlice defines fields of data from flags, environment variables, external source and default values.
+// It behaves like envconf.Parse without reflection over Slice
+func ParseSlice(data *Slice, opts ...option.ClientOption) error {
+	return ParseSliceWith(envconf.New(), data, opts...)
+}
+
+// ParseSliceWith defines fields of data with ec. See ParseSlice
+func ParseSliceWith(ec *envconf.EnvConf, data *Slice, opts ...option.ClientOption) error {
+	if data == nil {
+		return envconf.ErrNilData
+	}
+	fields := &envconfSliceFields
+	g := ec.Generated(opts...)
+	for i := range fields {
+		if err := g.Init(&fields[i]); err != nil {
+			return err
+		}
+	}
+	cfg := *data
+	if cfg.Field != nil {
+		cfg.Field = append(make([]int, 0, len(cfg.Field)), cfg.Field...)
+	}
+	var shadow Slice
+	var err error
+	if g.StrictPriority() {
+		err = g.Begin(&shadow)
+	} else {
+		err = g.Begin(&cfg)
+	}
+	if err != nil {
+		return err
+	}
+	// Field
+	if s, cs, err := g.Lookup(&fields[0]); err != nil {
+		return err
+	} else if cs == option.ExternalSource {
+		if g.StrictPriority() {
+			cfg.Field = shadow.Field
+		}
+		for j := range cfg.Field {
+			g.Defined(g.Item(&fields[0], j), cfg.Field[j], cs)
+		}
+		g.Defined(&fields[0], cfg.Field, cs)
+	} else if cs != option.NoConfigValue {
+		items := strings.Split(s, ",")
+		cfg.Field = make([]int, len(items))
+		for j, s := range items {
+			item := g.Item(&fields[0], j)
+			v, err := strconv.ParseInt(strings.Trim(s, " "), 0, strconv.IntSize)
+			if err != nil {
+				return g.ConversionError(item, s, cs, err)
+			}
+			cfg.Field[j] = int(v)
+			g.Defined(item, v, cs)
+		}
+		g.Defined(&fields[0], items, cs)
+	} else if len(cfg.Field) != 0 {
+		g.NotFound(g.Item(&fields[0], 0))
+	}
+	if err = g.End(); err != nil {
+		return err
+	}
+	*data = cfg
+	return nil
+}
+
+var envconfByteSliceFields = [...]envconf.GenField{
+	{
+		Name:       "Field",
+		FullName:   "Field",
+		Path:       []string{"Field"},
+		Type:       reflect.TypeOf((*[]byte)(nil)).Elem(),
+		Flag:       "-",
+		Env:        "-",
+		Default:    "abc",
+		HasDefault: true,
+		Slice:      true,
+	},
+}
+
+// ParseByteSlice defines fields of data from flags, environment variables, external source and default values.
+// It behaves like envconf.Parse without reflection over ByteSlice
+func ParseByteSlice(data *ByteSlice, opts ...option.ClientOption) error {
+	return ParseByteSliceWith(envconf.New(), data, opts...)
+}
+
+// ParseByteSliceWith defines fields of data with ec. See ParseByteSlice
+func ParseByteSliceWith(ec *envconf.EnvConf, data *ByteSlice, opts ...option.ClientOption) error {
+	if data == nil {
+		return envconf.ErrNilData
+	}
+	fields := &envconfByteSliceFields
+	g := ec.Generated(opts...)
+	for i := range fields {
+		if err := g.Init(&fields[i]); err != nil {
+			return err
+		}
+	}
+	cfg := *data
+	if cfg.Field != nil {
+		cfg.Field = append(make([]byte, 0, len(cfg.Field)), cfg.Field...)
+	}
+	var shadow ByteSlice
+	var err error
+	if g.StrictPriority() {
+		err = g.Begin(&shadow)
+	} else {
+		err = g.Begin(&cfg)
+	}
+	if err != nil {
+		return err
+	}
+	// Field
+	if s, cs, err := g.Lookup(&fields[0]); err != nil {
+		return err
+	} else if cs == option.ExternalSource {
+		if g.StrictPriority() {
+			cfg.Field = shadow.Field
+		}
+		for j := range cfg.Field {
+			g.Defined(g.Item(&fields[0], j), cfg.Field[j], cs)
+		}
+		g.Defined(&fields[0], cfg.Field, cs)
+	} else if cs != option.NoConfigValue {
+		cfg.Field = []byte(s)
+		g.Defined(&fields[0], s, cs)
+	} else if len(cfg.Field) != 0 {
+		g.NotFound(g.Item(&fields[0], 0))
+	}
+	if err = g.End(); err != nil {
+		return err
+	}
+	*data = cfg
+	return nil
+}
+
+var envconfStringPointerFields = [...]envconf.GenField{
+	{
+		Name:       "Field",
+		FullName:   "Field",
+		Path:       []string{"Field"},
+		Type:       reflect.TypeOf((**string)(nil)).Elem(),
+		Flag:       "-",
+		Env:        "-",
+		Default:    "test",
+		HasDefault: true,
+	},
+}
+
+// ParseStringPointer defines fields of data from flags, environment variables, external source and default values.
+// It behaves like envconf.Parse without reflection over StringPointer
+func ParseStringPointer(data *StringPointer, opts ...option.ClientOption) error {
+	return ParseStringPointerWith(envconf.New(), data, opts...)
+}
+
+// ParseStringPointerWith defines fields of data with ec. See ParseStringPointer
+func ParseStringPointerWith(ec *envconf.EnvConf, data *StringPointer, opts ...option.ClientOption) error {
+	if data == nil {
+		return envconf.ErrNilData
+	}
+	fields := &envconfStringPointerFields
+	g := ec.Generated(opts...)
+	for i := range fields {
+		if err := g.Init(&fields[i]); err != nil {
+			return err
+		}
+	}
+	cfg := *data
+	if cfg.Field != nil {
+		v := *cfg.Field
+		cfg.Field = &v
+	}
+	var shadow StringPointer
+	var err error
+	if g.StrictPriority() {
+		err = g.Begin(&shadow)
+	} else {
+		err = g.Begin(&cfg)
+	}
+	if err != nil {
+		return err
+	}
+	// Field
+	if s, cs, err := g.Lookup(&fields[0]); err != nil {
+		return err
+	} else if cs == option.ExternalSource {
+		if g.StrictPriority() {
+			cfg.Field = shadow.Field
+		}
+	} else if cs != option.NoConfigValue {
+		if cfg.Field == nil {
+			cfg.Field = new(string)
+		}
+		*cfg.Field = s
+		g.Defined(&fields[0], s, cs)
+	}
+	if err = g.End(); err != nil {
+		return err
+	}
+	*data = cfg
+	return nil
+}
@@ -0,0 +1,105 @@
+package crosscheck
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/antonmashko/envconf"
+	jsonconf "github.com/antonmashko/envconf/external/json"
+	"github.com/antonmashko/envconf/option"
+)
+
+// repoCheck runs cases of the test that struct is copied from
+func repoCheck[T any](t *testing.T, cases []crossCase, parse func(*envconf.EnvConf, *T, ...option.ClientOption) error) {
+	t.Helper()
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			crossCheck(t, envconf.New(), tc, func() *T { return new(T) }, parse)
+		})
+	}
+}
+
+func externalOpts(data string) func() []option.ClientOption {
+	return func() []option.ClientOption {
+		return []option.ClientOption{option.WithExternal(jsonconf.Json(data))}
+	}
+}
+
+func TestCrossCheck_Repo(t *testing.T) {
+	flatEnv := map[string]string{"TEST_FIELD_1": "1"}
+	for i := 2; i <= 14; i++ {
+		flatEnv["TEST_FIELD_"+strconv.Itoa(i)] = strconv.Itoa(i - 1)
+	}
+	t.Run("FlatDefault", func(t *testing.T) {
+		repoCheck(t, []crossCase{{name: "defaults"}}, ParseFlatDefaultWith)
+	})
+	t.Run("FlatEnv", func(t *testing.T) {
+		repoCheck(t, []crossCase{{name: "env", env: flatEnv}}, ParseFlatEnvWith)
+	})
+	t.Run("Nested", func(t *testing.T) {
+		repoCheck(t, []crossCase{{name: "defaults"}}, ParseNestedWith)
+	})
+	t.Run("RenamedParent", func(t *testing.T) {
+		repoCheck(t, []crossCase{{name: "env", env: map[string]string{"INNER_FIELD1": "ok"}}}, ParseRenamedParentWith)
+	})
+	t.Run("RequiredValue", func(t *testing.T) {
+		repoCheck(t, []crossCase{
+			{name: "defined", env: map[string]string{"TEST_FIELD1": "test123"}},
+			{name: "missing"},
+		}, ParseRequiredValueWith)
+	})
+	t.Run("SharedEnv", func(t *testing.T) {
+		env := map[string]string{"TEST_NAMES_SHARED_ENV": "value"}
+		repoCheck(t, []crossCase{
+			{name: "unique", env: env},
+			{name: "shared", env: env, opts: func() []option.ClientOption {
+				return []option.ClientOption{option.WithSharedEnv()}
+			}},
+		}, ParseSharedEnvWith)
+	})
+	t.Run("PriorityField", func(t *testing.T) {
+		repoCheck(t, []crossCase{{
+			name: "external",
+			env:  map[string]string{"TEST_PRIORITY_TAG_FIELD1": "from-env", "TEST_PRIORITY_TAG_FIELD2": "from-env"},
+			opts: externalOpts(`{"field1": "from-json", "field2": "from-json"}`),
+		}}, ParsePriorityFieldWith)
+	})
+	t.Run("PriorityInherited", func(t *testing.T) {
+		repoCheck(t, []crossCase{{
+			name: "env",
+			env:  map[string]string{"TEST_PRIORITY_TAG_INNER_FIELD1": "from-env", "TEST_PRIORITY_TAG_INNER_FIELD2": "from-env"},
+		}}, ParsePriorityInheritedWith)
+	})
+	t.Run("PriorityWithSources", func(t *testing.T) {
+		repoCheck(t, []crossCase{{name: "defaults"}}, ParsePriorityWithSourcesWith)
+	})
+	t.Run("AllowedSource", func(t *testing.T) {
+		repoCheck(t, []crossCase{{name: "env", env: map[string]string{"TEST_SOURCES_ALLOWED": "env"}}}, ParseAllowedSourceWith)
+	})
+	t.Run("DisallowedSource", func(t *testing.T) {
+		repoCheck(t, []crossCase{
+			{name: "provided", opts: externalOpts(`{"field": "external"}`)},
+			{name: "without value", opts: externalOpts(`{}`)},
+		}, ParseDisallowedSourceWith)
+	})
+	t.Run("Slice", func(t *testing.T) {
+		repoCheck(t, []crossCase{
+			{name: "env", env: map[string]string{"TEST_PARSE_SLICE_OK": "-2, -1,0, 1 ,2 "}},
+			{name: "invalid element", env: map[string]string{"TEST_PARSE_SLICE_OK": "-2,-1,0,x,i"}},
+			{name: "external", opts: externalOpts(`{"Field": [1, 2]}`)},
+			{name: "without value"},
+		}, ParseSliceWith)
+	})
+	t.Run("ByteSlice", func(t *testing.T) {
+		repoCheck(t, []crossCase{
+			{name: "defaults"},
+			{name: "external", opts: externalOpts(`{"Field": "eHl6"}`)},
+		}, ParseByteSliceWith)
+	})
+	t.Run("StringPointer", func(t *testing.T) {
+		repoCheck(t, []crossCase{
+			{name: "defaults"},
+			{name: "external", opts: externalOpts(`{"Field": "external"}`)},
+		}, ParseStringPointerWith)
+	})
+}
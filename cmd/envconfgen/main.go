@@ -0,0 +1,48 @@
+// Command envconfgen generates reflection-free parse functions for the structs with envconf tags.
+//
+//	//go:generate go run github.com/antonmashko/envconf/cmd/envconfgen -type Config
+//
+// For each type Parse<Type> and Parse<Type>With functions are generated into <type>_envconf.go.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+func main() {
+	typeNames := flag.String("type", "", "comma-separated list of struct type names; required")
+	output := flag.String("output", "", "output file name; default <type>_envconf.go")
+	flag.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: envconfgen -type T [-output file] [directory]\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if *typeNames == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+	dir := "."
+	if flag.NArg() > 0 {
+		dir = flag.Arg(0)
+	}
+	types := strings.Split(*typeNames, ",")
+	if *output == "" {
+		*output = strings.ToLower(types[0]) + "_envconf.go"
+	}
+	if !filepath.IsAbs(*output) {
+		*output = filepath.Join(dir, *output)
+	}
+	src, err := generate(dir, filepath.Base(*output), types)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "envconfgen:", err)
+		os.Exit(1)
+	}
+	if err = os.WriteFile(*output, src, 0o644); err != nil {
+		fmt.Fprintln(os.Stderr, "envconfgen:", err)
+		os.Exit(1)
+	}
+}
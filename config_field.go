@@ -2,11 +2,12 @@ package envconf
 
 import (
 	"flag"
-	"os"
+	"fmt"
 	"reflect"
-	"strconv"
 	"strings"
+	"sync"
 
+	"github.com/antonmashko/envconf/external"
 	"github.com/antonmashko/envconf/option"
 )
 
@@ -16,6 +17,14 @@ const (
 	tagDefault     = "default"
 	tagRequired    = "required"
 	tagDescription = "description"
+	tagEnum        = "enum"
+	tagMin         = "min"
+	tagMax         = "max"
+	tagPattern     = "pattern"
+	tagSecret      = "secret"
+	tagSources     = "sources"
+	tagPriority    = "priority"
+	tagReload      = "reload"
 	tagIgnored     = "-"
 	tagNotDefined  = ""
 
@@ -25,27 +34,41 @@ const (
 )
 
 type flagSource struct {
-	name    string
+	name string
+	// mu guards value that is set by flag.Parse of concurrent Parse calls
+	mu      sync.Mutex
 	v       string
 	defined bool
 }
 
-func newFlagSource(f *configField, tag reflect.StructField, usage string) *flagSource {
-	name, ok := tag.Tag.Lookup(tagFlag)
+// ignoredFlag is a flag source of the fields without flag. It's never registered, so it's shared by them
+var ignoredFlag = &flagSource{name: tagIgnored}
+
+func newFlagSource(f *configField) *flagSource {
+	name, ok := f.tag().flag, f.tag().hasFlag
 	if !ok || name == tagNotDefined {
-		name = tagIgnored
-	} else if name == valDefault {
+		return ignoredFlag
+	}
+	if name == valDefault {
 		// generating flag name
 		const flagDelim = "-"
 		name = strings.ToLower(fullname(f, flagDelim))
 	}
-	fs := &flagSource{
+	return &flagSource{
 		name: name,
 	}
-	if name != tagIgnored {
-		flag.Var(fs, name, usage)
+}
+
+// register defines flag in the flag set
+func (s *flagSource) register(fs *flag.FlagSet, usage string) error {
+	if s.name == tagIgnored {
+		return nil
+	}
+	if fs.Lookup(s.name) != nil {
+		return fmt.Errorf("flag %q is already defined", s.name)
 	}
-	return fs
+	fs.Var(s, s.name, usage)
+	return nil
 }
 
 func (s *flagSource) Name() string {
@@ -56,6 +79,8 @@ func (s *flagSource) Value() (interface{}, option.ConfigSource) {
 	if s.name == tagIgnored {
 		return "", option.NoConfigValue
 	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
 	if !s.defined {
 		return "", option.NoConfigValue
 	}
@@ -63,21 +88,26 @@ func (s *flagSource) Value() (interface{}, option.ConfigSource) {
 }
 
 func (s *flagSource) Set(value string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
 	s.v = value
 	s.defined = true
 	return nil
 }
 
 func (s *flagSource) String() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
 	return s.v
 }
 
 type envSource struct {
-	name string
+	name   string
+	parser *resolver
 }
 
-func newEnvSource(f *configField, tag reflect.StructField) *envSource {
-	name, ok := tag.Tag.Lookup(tagEnv)
+func newEnvSource(f *configField) *envSource {
+	name, ok := f.tag().env, f.tag().hasEnv
 	if !ok || name == tagNotDefined {
 		name = tagIgnored
 	} else if name == valDefault {
@@ -86,7 +116,8 @@ func newEnvSource(f *configField, tag reflect.StructField) *envSource {
 		name = strings.ToUpper(fullname(f, envDelim))
 	}
 	return &envSource{
-		name: name,
+		name:   name,
+		parser: f.parser,
 	}
 }
 
@@ -98,7 +129,7 @@ func (s *envSource) Value() (interface{}, option.ConfigSource) {
 	if s.name == tagIgnored {
 		return "", option.NoConfigValue
 	}
-	v, ok := os.LookupEnv(s.name)
+	v, ok := s.parser.lookupEnv(s.name)
 	if !ok {
 		return "", option.NoConfigValue
 	}
@@ -106,14 +137,14 @@ func (s *envSource) Value() (interface{}, option.ConfigSource) {
 }
 
 type externalSource struct {
-	f    field
-	opts *option.Options
+	f      field
+	parser *resolver
 }
 
-func newExternalSource(f field, opts *option.Options) *externalSource {
+func newExternalSource(f field, parser *resolver) *externalSource {
 	return &externalSource{
-		f:    f,
-		opts: opts,
+		f:      f,
+		parser: parser,
 	}
 }
 
@@ -126,7 +157,22 @@ func (s *externalSource) Value() (interface{}, option.ConfigSource) {
 	if !ok {
 		return nil, option.NoConfigValue
 	}
-	envInjF := s.opts.ExternalInjection()
+	return s.parser.injectEnv(v)
+}
+
+// Position returns position of the field value in the external source
+func (s *externalSource) Position() external.Position {
+	if s.f.parent() == nil {
+		return external.Position{}
+	}
+	pos, _ := external.PositionOf(s.f.parent().externalSource(), s.f.structField().Name)
+	return pos
+}
+
+// injectEnv replaces value of external source with environment variable
+// according to option.WithExternalInjection
+func (r *resolver) injectEnv(v interface{}) (interface{}, option.ConfigSource) {
+	envInjF := r.opts.ExternalInjection()
 	if envInjF == nil {
 		return v, option.ExternalSource
 	}
@@ -138,7 +184,7 @@ func (s *externalSource) Value() (interface{}, option.ConfigSource) {
 	str, cs = envInjF(str)
 	switch cs {
 	case option.EnvVariable:
-		v, cs = (&envSource{name: str}).Value()
+		v, cs = (&envSource{name: str, parser: r}).Value()
 		if cs == option.NoConfigValue {
 			return nil, option.NoConfigValue
 		}
@@ -153,10 +199,11 @@ type defaultValueSource struct {
 	v       string
 }
 
-func newDefaultValueSource(tag reflect.StructField) *defaultValueSource {
-	var s defaultValueSource
-	s.v, s.defined = tag.Tag.Lookup(tagDefault)
-	return &s
+func newDefaultValueSource(t *fieldTags) *defaultValueSource {
+	return &defaultValueSource{
+		v:       t.defaultValue,
+		defined: t.hasDefault,
+	}
 }
 
 func (s *defaultValueSource) Value() (interface{}, option.ConfigSource) {
@@ -167,9 +214,12 @@ func (s *defaultValueSource) Value() (interface{}, option.ConfigSource) {
 }
 
 type configField struct {
-	reflect.StructField
+	// StructField is shared with the struct plan, it isn't changed
+	*reflect.StructField
+	// tags are parsed tags of StructField. Use tag method for accessing them
+	tags          *fieldTags
 	parentField   field
-	parser        *EnvConf
+	parser        *resolver
 	configuration struct {
 		flag         *flagSource
 		env          *envSource
@@ -179,16 +229,26 @@ type configField struct {
 	property struct {
 		required    bool
 		description string
+		// sources is a mask of sources that are allowed for the field
+		sources option.ConfigSource
+		// priority overrides priority order of options. Defined by priority tag of the field or its parents
+		priority []option.ConfigSource
+		// order is the effective priority order of the field. See effectivePriorityOrder
+		order []option.ConfigSource
 	}
 	value  interface{}
 	source option.ConfigSource
+	// fname caches full name of the field
+	fname string
+	// tracked is set once field is added to the fields of resolution
+	tracked bool
 }
 
-func newConfigField(parent field, sf reflect.StructField, parser *EnvConf) *configField {
+func newConfigField(parent field, sf *reflect.StructField, parser *resolver) *configField {
 	return newDefinedConfigField(nil, option.NoConfigValue, parent, sf, parser)
 }
 
-func newDefinedConfigField(v interface{}, cs option.ConfigSource, parent field, sf reflect.StructField, parser *EnvConf) *configField {
+func newDefinedConfigField(v interface{}, cs option.ConfigSource, parent field, sf *reflect.StructField, parser *resolver) *configField {
 	return &configField{
 		StructField: sf,
 		parentField: parent,
@@ -200,28 +260,76 @@ func newDefinedConfigField(v interface{}, cs option.ConfigSource, parent field,
 
 // initialize setting for specific field
 func (f *configField) init(fl field) error {
-	req, ok := f.Tag.Lookup(tagRequired)
-	if ok {
-		var err error
-		f.property.required, err = strconv.ParseBool(req)
-		if err != nil {
-			return err
+	if f.configuration.flag != nil {
+		// already initialized
+		return nil
+	}
+	t := f.tag()
+	if t.requiredErr != nil {
+		return t.requiredErr
+	}
+	f.property.required = t.required
+	f.property.description = t.description
+	f.configuration.flag = newFlagSource(f)
+	f.configuration.env = newEnvSource(f)
+	f.configuration.external = newExternalSource(fl, f.parser)
+	f.configuration.defaultValue = newDefaultValueSource(t)
+	if err := f.parser.registerName("env", f.configuration.env.Name(), f.fullName()); err != nil {
+		return err
+	}
+	if err := f.parser.registerName("flag", f.configuration.flag.Name(), f.fullName()); err != nil {
+		return err
+	}
+	fs, err := f.parser.registerFlag(f.configuration.flag, f.property.description)
+	if err != nil {
+		return &Error{Inner: err, FieldName: f.fullName()}
+	}
+	f.configuration.flag = fs
+	if err := f.initSources(); err != nil {
+		return err
+	}
+	if dv := f.configuration.defaultValue; dv.defined {
+		if err := t.checkDefault(f.StructField.Type); err != nil {
+			return &Error{
+				Inner:     f.conversionError(dv.v, f.StructField.Type, option.DefaultValue, err),
+				FieldName: f.fullName(),
+				Message:   "invalid default value",
+			}
 		}
 	}
-	f.property.description = f.Tag.Get(tagDescription)
-	f.configuration.flag = newFlagSource(f, f.StructField, f.property.description)
-	f.configuration.env = newEnvSource(f, f.StructField)
-	f.configuration.external = newExternalSource(fl, f.parser.opts)
-	f.configuration.defaultValue = newDefaultValueSource(f.StructField)
 	return nil
 }
 
+// checkTags returns error if secret or reload tag of the field isn't a bool value.
+// Tags are checked for fields of any type, because nested fields inherit them
+func (f *configField) checkTags() error {
+	t := f.tag()
+	if t.secretErr != nil {
+		return &Error{Inner: t.secretErr, FieldName: f.fullName(), Message: "invalid secret tag"}
+	}
+	if t.reloadErr != nil {
+		return &Error{Inner: t.reloadErr, FieldName: f.fullName(), Message: "invalid reload tag"}
+	}
+	return nil
+}
+
+// tag returns parsed tags of the field. Tags of struct fields are taken from the cached plan
+func (f *configField) tag() *fieldTags {
+	if f.tags == nil {
+		f.tags = parseTags(f.Tag)
+	}
+	return f.tags
+}
+
 func (f *configField) name() string {
 	return f.Name
 }
 
 func (f *configField) fullName() string {
-	return fullname(f, fieldNameDelim)
+	if f.fname == "" {
+		f.fname = fullname(f, fieldNameDelim)
+	}
+	return f.fname
 }
 
 func (f *configField) parent() field {
@@ -232,7 +340,235 @@ func (f *configField) parent() field {
 }
 
 func (f *configField) structField() reflect.StructField {
-	return f.StructField
+	return *f.StructField
+}
+
+const allSources = option.FlagVariable | option.EnvVariable | option.ExternalSource | option.DefaultValue
+
+// initSources defines priority order and sources allowed by sources tag and by source policy of options
+func (f *configField) initSources() error {
+	priority, err := f.priorityTag()
+	if err != nil {
+		return &Error{Inner: err, FieldName: f.fullName(), Message: "invalid priority tag"}
+	}
+	f.property.priority = priority
+	f.property.sources = allSources
+	if t := f.tag(); t.hasSources {
+		if t.sourcesErr != nil {
+			return &Error{Inner: t.sourcesErr, FieldName: f.fullName(), Message: "invalid sources tag"}
+		}
+		f.property.sources = sourcesMask(t.sources)
+	}
+	if f.parser.opts.HasSourcePolicy() {
+		if sources := f.parser.opts.AllowedSources(initializedArg(f)); sources != nil {
+			f.property.sources &= sourcesMask(sources)
+		}
+	}
+	f.property.order = f.orderOf()
+	return nil
+}
+
+// priorityTag returns priority order from the priority tag of the field or the closest parent
+func (f *configField) priorityTag() ([]option.ConfigSource, error) {
+	t := f.tag()
+	for fl := f.parent(); !t.hasPriority && fl != nil; fl = fl.parent() {
+		t = tagsOf(fl)
+	}
+	if !t.hasPriority {
+		return nil, nil
+	}
+	sources, err := t.priority, t.priorityErr
+	if err != nil {
+		return nil, err
+	}
+	// the same semantic as option.WithPriorityOrder: duplicates are ignored
+	result := make([]option.ConfigSource, 0, len(sources))
+	var mask option.ConfigSource
+	for _, cs := range sources {
+		if mask&cs == 0 {
+			result = append(result, cs)
+			mask |= cs
+		}
+	}
+	return result, nil
+}
+
+// priorityOrder returns priority order of the field
+func (f *configField) priorityOrder() []option.ConfigSource {
+	if f.property.priority != nil {
+		return f.property.priority
+	}
+	return f.parser.PriorityOrder()
+}
+
+// effectivePriorityOrder returns sources of the field in the priority order
+// without sources that aren't allowed. The order is computed once sources of the field are initialized
+func (f *configField) effectivePriorityOrder() []option.ConfigSource {
+	if f.property.order != nil {
+		return f.property.order
+	}
+	return f.orderOf()
+}
+
+func (f *configField) orderOf() []option.ConfigSource {
+	if f.property.sources == allSources {
+		return f.priorityOrder()
+	}
+	result := make([]option.ConfigSource, 0, 4)
+	for _, p := range f.priorityOrder() {
+		if f.allowed(p) {
+			result = append(result, p)
+		}
+	}
+	return result
+}
+
+func sourcesMask(sources []option.ConfigSource) option.ConfigSource {
+	var mask option.ConfigSource
+	for _, cs := range sources {
+		mask |= cs
+	}
+	return mask
+}
+
+func (f *configField) allowed(cs option.ConfigSource) bool {
+	return f.property.sources&cs != 0
+}
+
+// checkSources returns error if source that isn't allowed for the field provided a value
+func (f *configField) checkSources() error {
+	if f.property.sources == allSources {
+		return nil
+	}
+	for _, p := range f.priorityOrder() {
+		if f.allowed(p) {
+			continue
+		}
+		if _, cs := f.sourceValue(p); cs == option.NoConfigValue {
+			continue
+		}
+		return &Error{
+			Inner:     &SourceError{FieldName: f.fullName(), Source: p, Allowed: f.effectivePriorityOrder()},
+			FieldName: f.fullName(),
+			Message:   "source is not allowed",
+		}
+	}
+	return nil
+}
+
+// shadowValue returns value of the field in the shadow copy of data with values of external source
+func (f *configField) shadowValue() (reflect.Value, bool) {
+	if f.parentField == nil {
+		return f.parser.shadow, f.parser.shadow.IsValid()
+	}
+	p, ok := f.parentField.(interface{ config() *configField })
+	if !ok || len(f.Index) == 0 {
+		return reflect.Value{}, false
+	}
+	rv, ok := p.config().shadowValue()
+	if !ok {
+		return reflect.Value{}, false
+	}
+	for rv.Kind() == reflect.Ptr || rv.Kind() == reflect.Interface {
+		if rv.IsNil() {
+			return reflect.Value{}, false
+		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct || rv.Type().NumField() <= f.Index[0] ||
+		rv.Type().Field(f.Index[0]).Name != f.Name {
+		return reflect.Value{}, false
+	}
+	return rv.Field(f.Index[0]), true
+}
+
+// assignExternal sets value of external source into rv. Value is taken from the shadow copy of data.
+// Does nothing if external source was unmarshaled directly into data
+func (f *configField) assignExternal(rv reflect.Value) {
+	if !f.parser.opts.StrictPriority() || !rv.CanSet() {
+		return
+	}
+	sv, ok := f.shadowValue()
+	if !ok {
+		// value was assigned with the parent collection
+		return
+	}
+	for sv.Type() != rv.Type() && sv.Kind() == reflect.Ptr {
+		if sv.IsNil() {
+			return
+		}
+		sv = sv.Elem()
+	}
+	if sv.Type() == rv.Type() {
+		rv.Set(sv)
+	}
+}
+
+func (f *configField) config() *configField {
+	return f
+}
+
+// isSecret reports whether field or any of its parents is classified as secret
+// by the secret tag or by the name matcher of options. Fields of Secret type are always secret
+func (f *configField) isSecret() bool {
+	if f.StructField.Type != nil && isSecretType(f.StructField.Type) {
+		return true
+	}
+	if f.tag().secret || f.parser.opts.IsSecret(f.Name) {
+		return true
+	}
+	for fl := f.parent(); fl != nil; fl = fl.parent() {
+		if tagsOf(fl).secret {
+			return true
+		}
+		if f.parser.opts.IsSecret(fl.name()) {
+			return true
+		}
+	}
+	return false
+}
+
+// isReloadable returns true if field or any of its parents is marked with reload tag
+func (f *configField) isReloadable() bool {
+	if f.tag().reload {
+		return true
+	}
+	for fl := f.parent(); fl != nil; fl = fl.parent() {
+		if tagsOf(fl).reload {
+			return true
+		}
+	}
+	return false
+}
+
+// externalValue reads value of the field from the root external source ext by names of the parent structs.
+// External sources of the fields are set by define, it's used when external source fails to map into the struct
+func (f *configField) externalValue(ext external.ExternalSource) (interface{}, bool) {
+	path := []string{f.StructField.Name}
+	for p := f.parent(); p != nil && p.parent() != nil; p = p.parent() {
+		path = append([]string{p.structField().Name}, path...)
+	}
+	for _, name := range path[:len(path)-1] {
+		ext = external.AsExternalSource(name, ext)
+	}
+	return ext.Read(path[len(path)-1])
+}
+
+// conversionError creates error of converting value v from source cs into type rt.
+// Raw value is redacted for secret fields
+func (f *configField) conversionError(v interface{}, rt reflect.Type, cs option.ConfigSource, err error) *ConversionError {
+	ce := &ConversionError{
+		FieldName: f.fullName(),
+		Value:     v,
+		Type:      rt,
+		Source:    cs,
+		Err:       err,
+	}
+	if f.isSecret() {
+		ce.Value = option.SecretMask
+		ce.Err = redact(err, v)
+	}
+	return ce
 }
 
 func (f *configField) IsRequired() bool {
@@ -253,26 +589,48 @@ func (f *configField) Value() (interface{}, option.ConfigSource) {
 	if f.isSet() {
 		return f.value, f.source
 	}
-	priority := f.parser.PriorityOrder()
-	for _, p := range priority {
-		var confF func() (interface{}, option.ConfigSource) = nil
-		switch p {
-		case option.FlagVariable:
-			confF = f.configuration.flag.Value
-		case option.EnvVariable:
-			confF = f.configuration.env.Value
-		case option.ExternalSource:
-			confF = f.configuration.external.Value
-		case option.DefaultValue:
-			confF = f.configuration.defaultValue.Value
-		}
-		if confF == nil {
-			continue
-		}
-		v, cs := confF()
-		if cs != option.NoConfigValue {
+	for _, p := range f.effectivePriorityOrder() {
+		if v, cs := f.sourceValue(p); cs != option.NoConfigValue {
 			return v, cs
 		}
 	}
 	return nil, option.NoConfigValue
 }
+
+// lookup returns values from all configuration sources that define field
+// in the priority order. First element is the value that wins.
+func (f *configField) lookup() []Candidate {
+	var result []Candidate
+	for _, p := range f.effectivePriorityOrder() {
+		v, cs := f.sourceValue(p)
+		if cs != option.NoConfigValue {
+			result = append(result, Candidate{Value: v, Source: cs, Position: f.sourcePosition(cs)})
+		}
+	}
+	return result
+}
+
+// sourcePosition returns position of the field value in the source cs.
+// Only external source reports positions
+func (f *configField) sourcePosition(cs option.ConfigSource) external.Position {
+	if cs != option.ExternalSource {
+		return external.Position{}
+	}
+	return f.configuration.external.Position()
+}
+
+// sourceValue returns value of the field from the configuration source p
+func (f *configField) sourceValue(p option.ConfigSource) (interface{}, option.ConfigSource) {
+	switch p {
+	case option.FlagVariable:
+		return f.configuration.flag.Value()
+	case option.EnvVariable:
+		return f.configuration.env.Value()
+	case option.ExternalSource:
+		return f.configuration.external.Value()
+	case option.DefaultValue:
+		return f.configuration.defaultValue.Value()
+	default:
+		return nil, option.NoConfigValue
+	}
+}
@@ -0,0 +1,135 @@
+package envconf
+
+import (
+	"errors"
+	"flag"
+	"io"
+	"reflect"
+
+	"github.com/antonmashko/envconf/option"
+)
+
+// FieldDescription describes configuration field and its metadata
+type FieldDescription struct {
+	Name     string
+	FullName string
+	Type     reflect.Type
+	Tag      reflect.StructTag
+
+	// FlagName and EnvName are empty if field can't be defined from flag or environment variable
+	FlagName     string
+	EnvName      string
+	DefaultValue string
+	HasDefault   bool
+	Required     bool
+	Description  string
+	// Secret is true if field holds sensitive value. DefaultValue is redacted for such fields
+	Secret bool
+
+	// KeyType and ElemType are set for collections (KeyType only for maps)
+	KeyType  reflect.Type
+	ElemType reflect.Type
+	// Fields of the nested struct
+	Fields []FieldDescription
+}
+
+// Describe returns tree of configuration fields of data without parsing it.
+// Describe doesn't register flags and doesn't read environment variables
+func Describe(data interface{}) ([]FieldDescription, error) {
+	if data == nil {
+		return nil, ErrNilData
+	}
+	rt := reflect.TypeOf(data)
+	for rt.Kind() == reflect.Ptr {
+		rt = rt.Elem()
+	}
+	if rt.Kind() != reflect.Struct {
+		return nil, errors.New("invalid type")
+	}
+	e := New()
+	e.flagSet = flag.NewFlagSet(rt.String(), flag.ContinueOnError)
+	e.flagSet.SetOutput(io.Discard)
+	// working with zero value for preventing data mutation
+	s := newStructType(reflect.New(rt).Elem(), newConfigField(nil, &reflect.StructField{}, newResolver(e, e.opts)))
+	if err := s.init(); err != nil {
+		return nil, err
+	}
+	return describeFields(s.fields), nil
+}
+
+func describeFields(fields []field) []FieldDescription {
+	result := make([]FieldDescription, 0, len(fields))
+	for _, f := range fields {
+		if !f.structField().IsExported() {
+			continue
+		}
+		fd, ok := describeField(f)
+		if !ok {
+			continue
+		}
+		result = append(result, fd)
+	}
+	return result
+}
+
+func describeField(f field) (FieldDescription, bool) {
+	switch ft := f.(type) {
+	case *structType:
+		return FieldDescription{
+			Name:     ft.name(),
+			FullName: fullname(ft, fieldNameDelim),
+			Type:     ft.StructField.Type,
+			Tag:      ft.Tag,
+			Fields:   describeFields(ft.fields),
+		}, true
+	case *ptrType:
+		fd, ok := describeField(ft.f)
+		fd.Type = ft.StructField.Type
+		return fd, ok
+	case *collectionType:
+		fd := describeConfigField(ft.configField)
+		if ft.v.Kind() == reflect.Map {
+			fd.KeyType = ft.v.Type().Key()
+		}
+		fd.ElemType = ft.v.Type().Elem()
+		return fd, true
+	case *sliceType:
+		return describeField(ft.collectionType)
+	case *mapType:
+		return describeField(ft.collectionType)
+	case *fieldType:
+		return describeConfigField(ft.configField), true
+	case *customSetFieldType:
+		return describeConfigField(ft.configField), true
+	case *interfaceType:
+		return describeConfigField(ft.configField), true
+	default:
+		return FieldDescription{}, false
+	}
+}
+
+func describeConfigField(cf *configField) FieldDescription {
+	fd := FieldDescription{
+		Name:         cf.name(),
+		FullName:     cf.fullName(),
+		Type:         cf.StructField.Type,
+		Tag:          cf.Tag,
+		FlagName:     cf.configuration.flag.Name(),
+		EnvName:      cf.configuration.env.Name(),
+		DefaultValue: cf.configuration.defaultValue.v,
+		HasDefault:   cf.configuration.defaultValue.defined,
+		Required:     cf.property.required,
+		Description:  cf.property.description,
+		Secret:       cf.isSecret(),
+	}
+	if fd.Secret && fd.HasDefault {
+		fd.DefaultValue = option.SecretMask
+	}
+	if fd.FlagName == tagIgnored {
+		fd.FlagName = ""
+	}
+	if fd.EnvName == tagIgnored {
+		fd.EnvName = ""
+	}
+	return fd
+}
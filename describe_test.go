@@ -0,0 +1,76 @@
+package envconf_test
+
+import (
+	"flag"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/antonmashko/envconf"
+)
+
+func TestDescribe_FieldTree_Ok(t *testing.T) {
+	t.Setenv("TEST_DESCRIBE_ADDR", "should-not-be-read")
+	cfg := struct {
+		HTTP struct {
+			Addr    string        `flag:"describe-addr" env:"TEST_DESCRIBE_ADDR" default:":8080" required:"true" description:"listen address"`
+			Timeout time.Duration `env:"*"`
+		} `envconf:"server"`
+		Hosts  []string          `flag:"*"`
+		Labels map[string]int    `env:"*"`
+		Ptr    *struct{ F bool } `json:"ptr"`
+		hidden string
+	}{}
+	fields, err := envconf.Describe(&cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.HTTP.Addr != "" || cfg.Ptr != nil {
+		t.Fatalf("data was modified: %#v", cfg)
+	}
+	if flag.Lookup("describe-addr") != nil || flag.Lookup("hosts") != nil {
+		t.Fatal("flag registered globally")
+	}
+	if len(fields) != 4 {
+		t.Fatalf("unexpected number of fields: %d", len(fields))
+	}
+	if strings.Contains(fmt.Sprintf("%+v", fields), "should-not-be-read") {
+		t.Fatalf("environment variable is read: %+v", fields)
+	}
+	http := fields[0]
+	if http.Name != "server" || len(http.Fields) != 2 {
+		t.Fatalf("unexpected struct description: %#v", http)
+	}
+	addr := http.Fields[0]
+	if addr.FullName != "server.Addr" || addr.FlagName != "describe-addr" ||
+		addr.EnvName != "TEST_DESCRIBE_ADDR" || addr.DefaultValue != ":8080" || !addr.HasDefault ||
+		!addr.Required || addr.Description != "listen address" || addr.Type != reflect.TypeOf("") {
+		t.Fatalf("unexpected field description: %#v", addr)
+	}
+	if http.Fields[1].EnvName != "SERVER_TIMEOUT" || http.Fields[1].FlagName != "" {
+		t.Fatalf("unexpected field description: %#v", http.Fields[1])
+	}
+	hosts := fields[1]
+	if hosts.FlagName != "hosts" || hosts.ElemType != reflect.TypeOf("") || hosts.KeyType != nil {
+		t.Fatalf("unexpected slice description: %#v", hosts)
+	}
+	labels := fields[2]
+	if labels.EnvName != "LABELS" || labels.KeyType != reflect.TypeOf("") || labels.ElemType != reflect.TypeOf(0) {
+		t.Fatalf("unexpected map description: %#v", labels)
+	}
+	ptr := fields[3]
+	if ptr.Tag.Get("json") != "ptr" || ptr.Type.Kind() != reflect.Ptr || len(ptr.Fields) != 1 {
+		t.Fatalf("unexpected pointer description: %#v", ptr)
+	}
+}
+
+func TestDescribe_InvalidData_Err(t *testing.T) {
+	if _, err := envconf.Describe(nil); err != envconf.ErrNilData {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := envconf.Describe(123); err == nil {
+		t.Fatal("expected error but got nil")
+	}
+}
@@ -1,8 +1,16 @@
 package envconf
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/antonmashko/envconf/external"
+	"github.com/antonmashko/envconf/jsonschema"
+	"github.com/antonmashko/envconf/option"
 )
 
 var (
@@ -16,6 +24,8 @@ type Error struct {
 	Inner     error
 	Message   string
 	FieldName string
+	// Position of the external source value. Invalid if value isn't from external source
+	Position external.Position
 }
 
 func (e *Error) Error() string {
@@ -23,6 +33,9 @@ func (e *Error) Error() string {
 	if e.FieldName != "" {
 		msg = fmt.Sprintf("%s: %s", e.FieldName, msg)
 	}
+	if e.Position.IsValid() {
+		msg += fmt.Sprintf(" (%s)", e.Position)
+	}
 	if e.Inner != nil {
 		msg += " " + e.Inner.Error()
 	}
@@ -32,3 +45,152 @@ func (e *Error) Error() string {
 func (e *Error) Unwrap() error {
 	return e.Inner
 }
+
+// RequiredError is returned when required field has no value in any source.
+// It matches ErrConfigurationNotFound with errors.Is
+type RequiredError struct {
+	FieldName string
+}
+
+func (e *RequiredError) Error() string {
+	return ErrConfigurationNotFound.Error()
+}
+
+func (e *RequiredError) Unwrap() error {
+	return ErrConfigurationNotFound
+}
+
+// ConversionError is returned when value from the source cannot be converted into field type
+type ConversionError struct {
+	FieldName string
+	// Value is a raw value received from the source
+	Value  interface{}
+	Type   reflect.Type
+	Source option.ConfigSource
+	Err    error
+}
+
+func (e *ConversionError) Error() string {
+	return fmt.Sprintf("type=%s source=%s. %s", e.Type, e.Source, e.Err)
+}
+
+func (e *ConversionError) Unwrap() error {
+	return e.Err
+}
+
+// SourceError is returned when source that isn't allowed for the field provided a value
+type SourceError struct {
+	FieldName string
+	Source    option.ConfigSource
+	// Allowed sources of the field in the priority order
+	Allowed []option.ConfigSource
+}
+
+func (e *SourceError) Error() string {
+	return fmt.Sprintf("source=%s allowed=%s", e.Source, e.Allowed)
+}
+
+// ExternalMappingError is returned when external source cannot be mapped into the struct.
+// Path contains keys of the external source
+type ExternalMappingError = external.MappingError
+
+// ValidationError is returned when external source doesn't match JSON Schema
+type ValidationError struct {
+	Violations jsonschema.Violations
+}
+
+func (e *ValidationError) Error() string {
+	return e.Violations.Error()
+}
+
+func (e *ValidationError) Unwrap() error {
+	return e.Violations
+}
+
+// LoadError is returned when provider of external data fails to load.
+// Err is an error of the context if provider didn't finish before the context was done
+type LoadError struct {
+	// Source is a name of the provider
+	Source string
+	Err    error
+}
+
+func (e *LoadError) Error() string {
+	if errors.Is(e.Err, context.DeadlineExceeded) || errors.Is(e.Err, context.Canceled) {
+		return fmt.Sprintf("provider %q stalled: %s", e.Source, e.Err)
+	}
+	return fmt.Sprintf("provider %q: %s", e.Source, e.Err)
+}
+
+func (e *LoadError) Unwrap() error {
+	return e.Err
+}
+
+// ReloadError is returned by reload when fields that aren't marked as reloadable changed
+type ReloadError struct {
+	FieldNames []string
+}
+
+func (e *ReloadError) Error() string {
+	return fmt.Sprintf("fields are not reloadable: %s", strings.Join(e.FieldNames, ", "))
+}
+
+// redactedError hides raw value in the text of the inner error.
+// Inner error isn't unwrapped, so its fields with raw value can't be reached with errors.As
+type redactedError struct {
+	err   error
+	value string
+}
+
+// redact hides v in the text of err. Values of external sources are also numbers
+func redact(err error, v interface{}) error {
+	var str string
+	switch rv := reflect.ValueOf(v); rv.Kind() {
+	case reflect.String:
+		str = rv.String()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		str = strconv.FormatInt(rv.Int(), 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		str = strconv.FormatUint(rv.Uint(), 10)
+	case reflect.Float32, reflect.Float64:
+		str = strconv.FormatFloat(rv.Float(), 'f', -1, 64)
+	}
+	if err == nil || str == "" {
+		return err
+	}
+	return &redactedError{err: err, value: str}
+}
+
+// redactMapping hides values of secret fields in the error of mapping external source,
+// decoders quote raw values in their errors
+func redactMapping(err error, secrets []interface{}) error {
+	var me *ExternalMappingError
+	if !errors.As(err, &me) {
+		return err
+	}
+	for _, v := range secrets {
+		me.Err = redact(me.Err, v)
+	}
+	return err
+}
+
+func (e *redactedError) Error() string {
+	return strings.ReplaceAll(e.err.Error(), e.value, option.SecretMask)
+}
+
+func (e *redactedError) Is(target error) bool {
+	return errors.Is(e.err, target)
+}
+
+func unknownKeysError(args []option.UnknownKeyArg) error {
+	keys := make([]string, len(args))
+	for i, arg := range args {
+		keys[i] = arg.Key
+		if arg.Suggestion != "" {
+			keys[i] += fmt.Sprintf(" (did you mean %q?)", arg.Suggestion)
+		}
+	}
+	return &Error{
+		Message: fmt.Sprintf("unknown keys in %s source: %s", args[0].Source, strings.Join(keys, ", ")),
+	}
+}
@@ -0,0 +1,151 @@
+package consul
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/antonmashko/envconf/external"
+)
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// assign stores tree of the values into v. Strings are converted by type of the target:
+// encoding.TextUnmarshaler, time.Duration and string types are set from the string as is,
+// other values are decoded as JSON, e.g. numbers and booleans
+func assign(v interface{}, tree map[string]interface{}) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() {
+		return fmt.Errorf("unable to assign into %T", v)
+	}
+	return assignValue(rv.Elem(), tree, nil)
+}
+
+func assignValue(rv reflect.Value, v interface{}, path []string) error {
+	if rv.Kind() == reflect.Pointer {
+		if rv.IsNil() {
+			rv.Set(reflect.New(rv.Type().Elem()))
+		}
+		return assignValue(rv.Elem(), v, path)
+	}
+	if rv.Kind() == reflect.Interface && rv.NumMethod() == 0 {
+		rv.Set(reflect.ValueOf(v))
+		return nil
+	}
+	switch vt := v.(type) {
+	case string:
+		if err := setString(rv, vt); err != nil {
+			return fmt.Errorf("%s: %w", strings.Join(path, "/"), err)
+		}
+		return nil
+	case map[string]interface{}:
+		switch rv.Kind() {
+		case reflect.Struct:
+			return assignStruct(rv, vt, path)
+		case reflect.Map:
+			return assignMap(rv, vt, path)
+		}
+	case []interface{}:
+		switch rv.Kind() {
+		case reflect.Slice:
+			if rv.Len() < len(vt) {
+				sl := reflect.MakeSlice(rv.Type(), len(vt), len(vt))
+				reflect.Copy(sl, rv)
+				rv.Set(sl)
+			}
+			rv.SetLen(len(vt))
+			fallthrough
+		case reflect.Array:
+			for i := 0; i < len(vt) && i < rv.Len(); i++ {
+				if err := assignValue(rv.Index(i), vt[i], append(path[:len(path):len(path)], fmt.Sprint(i))); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+	}
+	return fmt.Errorf("%s: unable to assign %T into %s", strings.Join(path, "/"), v, rv.Type())
+}
+
+// assignStruct matches keys with fields in the same way as external.ExternalConfigMapper does.
+// Promoted fields of embedded structs are assigned after fields of the struct, so they override
+// values of the embedded structs defined by their names
+func assignStruct(rv reflect.Value, mp map[string]interface{}, path []string) error {
+	rt := rv.Type()
+	var promoted []string
+	for k, v := range mp {
+		i := fieldIndex(rt, k)
+		if i == -1 {
+			if external.PromotedIndex(rt, k, tagNames) != nil {
+				promoted = append(promoted, k)
+			}
+			continue
+		}
+		if err := assignValue(rv.Field(i), v, append(path[:len(path):len(path)], k)); err != nil {
+			return err
+		}
+	}
+	for _, k := range promoted {
+		index := external.PromotedIndex(rt, k, tagNames)
+		if err := assignValue(rv.FieldByIndex(index), mp[k], append(path[:len(path):len(path)], k)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// fieldIndex returns index of the exported field that key defines. Returns -1 if key doesn't match any field
+func fieldIndex(rt reflect.Type, key string) int {
+	for i := 0; i < rt.NumField(); i++ {
+		sf := rt.Field(i)
+		if sf.IsExported() && external.MatchKey(key, sf, tagNames) {
+			return i
+		}
+	}
+	return -1
+}
+
+func assignMap(rv reflect.Value, mp map[string]interface{}, path []string) error {
+	rt := rv.Type()
+	if rt.Key().Kind() != reflect.String {
+		return fmt.Errorf("%s: unable to assign object into %s", strings.Join(path, "/"), rt)
+	}
+	if rv.IsNil() {
+		rv.Set(reflect.MakeMapWithSize(rt, len(mp)))
+	}
+	for k, v := range mp {
+		key := reflect.ValueOf(k).Convert(rt.Key())
+		ev := reflect.New(rt.Elem()).Elem()
+		if cur := rv.MapIndex(key); cur.IsValid() {
+			ev.Set(cur)
+		}
+		if err := assignValue(ev, v, append(path[:len(path):len(path)], k)); err != nil {
+			return err
+		}
+		rv.SetMapIndex(key, ev)
+	}
+	return nil
+}
+
+func setString(rv reflect.Value, str string) error {
+	if tu, ok := rv.Addr().Interface().(encoding.TextUnmarshaler); ok {
+		return tu.UnmarshalText([]byte(str))
+	}
+	switch {
+	case rv.Type() == durationType:
+		d, err := time.ParseDuration(str)
+		if err != nil {
+			return err
+		}
+		rv.SetInt(int64(d))
+		return nil
+	case rv.Kind() == reflect.String:
+		rv.SetString(str)
+		return nil
+	default:
+		return json.Unmarshal([]byte(str), rv.Addr().Interface())
+	}
+}
@@ -0,0 +1,175 @@
+package consul
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/antonmashko/envconf/external"
+)
+
+const defaultWait = 5 * time.Minute
+
+// Source loads keys under the prefix from Consul KV store over HTTP API.
+// Source implements external.Provider and external.Watcher, so it can be passed to option.WithProvider
+// and watched by EnvConf.Watch with blocking queries
+type Source struct {
+	addr   string
+	prefix string
+	token  string
+	dc     string
+	wait   time.Duration
+	client *http.Client
+
+	mu sync.Mutex
+	// index and data of the last response
+	index string
+	data  KV
+}
+
+// Option configures Source
+type Option func(*Source)
+
+// WithToken sets ACL token of the requests
+func WithToken(token string) Option {
+	return func(s *Source) {
+		s.token = token
+	}
+}
+
+// WithDatacenter sets datacenter of the requests. Default: datacenter of the agent
+func WithDatacenter(dc string) Option {
+	return func(s *Source) {
+		s.dc = dc
+	}
+}
+
+// WithWait sets maximum duration of the blocking query. Default: 5m
+func WithWait(d time.Duration) Option {
+	return func(s *Source) {
+		s.wait = d
+	}
+}
+
+// WithClient sets HTTP client of the requests. Default: client with default transport
+func WithClient(c *http.Client) Option {
+	return func(s *Source) {
+		s.client = c
+	}
+}
+
+// New returns Source of the keys under prefix, e.g. `service/billing/`.
+// addr is an address of Consul agent, e.g. http://127.0.0.1:8500.
+// Prefix is removed from the keys, so `service/billing/db/host` defines field DB.Host.
+// Prefix is a folder: trailing slash is added if it's missing, so `service/billing` doesn't match `service/billing-old/`
+func New(addr, prefix string, opts ...Option) *Source {
+	if prefix != "" && !strings.HasSuffix(prefix, "/") {
+		prefix += "/"
+	}
+	s := &Source{
+		addr:   strings.TrimSuffix(addr, "/"),
+		prefix: prefix,
+		wait:   defaultWait,
+		client: &http.Client{},
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// Name returns address and prefix of the source
+func (s *Source) Name() string {
+	return s.addr + "/" + s.prefix
+}
+
+// Load lists keys under the prefix
+func (s *Source) Load(ctx context.Context) (external.External, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	kv, index, err := s.list(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+	s.data, s.index = kv, index
+	return kv, nil
+}
+
+// Changed waits until keys under the prefix are modified or wait duration of the blocking query elapses.
+// Reports whether keys differ from the last loaded. Load isn't blocked while Changed waits
+func (s *Source) Changed(ctx context.Context) (bool, error) {
+	s.mu.Lock()
+	last := s.index
+	s.mu.Unlock()
+	kv, index, err := s.list(ctx, last)
+	if err != nil {
+		return false, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	// index of Consul may change without changes of the keys. Index of Load called meanwhile is kept
+	if s.index == last {
+		s.index = index
+	}
+	return !reflect.DeepEqual(kv, s.data), nil
+}
+
+type pair struct {
+	Key   string
+	Value []byte
+}
+
+// list requests keys under the prefix. If index isn't empty, request blocks until index changes
+func (s *Source) list(ctx context.Context, index string) (KV, string, error) {
+	q := url.Values{"recurse": {"true"}}
+	if s.dc != "" {
+		q.Set("dc", s.dc)
+	}
+	if index != "" {
+		q.Set("index", index)
+		q.Set("wait", strconv.FormatInt(s.wait.Milliseconds(), 10)+"ms")
+	}
+	u := s.addr + "/v1/kv/" + (&url.URL{Path: s.prefix}).EscapedPath() + "?" + q.Encode()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, "", err
+	}
+	if s.token != "" {
+		req.Header.Set("X-Consul-Token", s.token)
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, "", err
+	}
+	defer resp.Body.Close()
+	index = resp.Header.Get("X-Consul-Index")
+	kv := make(KV)
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		// prefix doesn't have keys
+		return kv, index, nil
+	default:
+		return nil, "", fmt.Errorf("unexpected status: %s", resp.Status)
+	}
+	var pairs []pair
+	if err := json.NewDecoder(resp.Body).Decode(&pairs); err != nil {
+		return nil, "", err
+	}
+	for _, p := range pairs {
+		key := strings.TrimPrefix(strings.TrimPrefix(p.Key, s.prefix), "/")
+		// folders and the prefix itself don't have values
+		if key == "" || strings.HasSuffix(key, "/") {
+			continue
+		}
+		kv[key] = string(p.Value)
+	}
+	return kv, index, nil
+}
@@ -0,0 +1,321 @@
+package consul
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/antonmashko/envconf"
+	"github.com/antonmashko/envconf/option"
+)
+
+// kvServer implements KV endpoints of Consul HTTP API with blocking queries
+type kvServer struct {
+	mu      sync.Mutex
+	token   string
+	index   int
+	keys    map[string]string
+	changed chan struct{}
+}
+
+func newKVServer(keys map[string]string) *kvServer {
+	return &kvServer{index: 1, keys: keys, changed: make(chan struct{})}
+}
+
+func (s *kvServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !strings.HasPrefix(r.URL.Path, "/v1/kv/") {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	if s.token != "" && r.Header.Get("X-Consul-Token") != s.token {
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+	key := strings.TrimPrefix(r.URL.Path, "/v1/kv/")
+	switch r.Method {
+	case http.MethodGet:
+		s.get(w, r, key)
+	case http.MethodPut:
+		b, _ := io.ReadAll(r.Body)
+		s.update(func() { s.keys[key] = string(b) })
+		w.Write([]byte("true"))
+	case http.MethodDelete:
+		s.update(func() { delete(s.keys, key) })
+		w.Write([]byte("true"))
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func (s *kvServer) update(fn func()) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	fn()
+	s.index++
+	close(s.changed)
+	s.changed = make(chan struct{})
+}
+
+func (s *kvServer) get(w http.ResponseWriter, r *http.Request, key string) {
+	s.mu.Lock()
+	if index := r.URL.Query().Get("index"); index == strconv.Itoa(s.index) {
+		wait, err := time.ParseDuration(r.URL.Query().Get("wait"))
+		if err != nil {
+			wait = 5 * time.Minute
+		}
+		changed := s.changed
+		s.mu.Unlock()
+		select {
+		case <-changed:
+		case <-time.After(wait):
+		case <-r.Context().Done():
+			return
+		}
+		s.mu.Lock()
+	}
+	defer s.mu.Unlock()
+	var pairs []pair
+	for k, v := range s.keys {
+		if k == key || r.URL.Query().Has("recurse") && strings.HasPrefix(k, key) {
+			pairs = append(pairs, pair{Key: k, Value: []byte(v)})
+		}
+	}
+	sort.Slice(pairs, func(i, j int) bool { return pairs[i].Key < pairs[j].Key })
+	w.Header().Set("X-Consul-Index", strconv.Itoa(s.index))
+	if len(pairs) == 0 {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	json.NewEncoder(w).Encode(pairs)
+}
+
+func put(t *testing.T, addr, key, value string) {
+	t.Helper()
+	req, err := http.NewRequest(http.MethodPut, addr+"/v1/kv/"+key, strings.NewReader(value))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+}
+
+type billingConfig struct {
+	DB struct {
+		Host string
+		Port int `reload:"true"`
+	}
+	Hosts   []string
+	Timeout time.Duration `consul:"request_timeout"`
+}
+
+func TestKV_Tree_Ok(t *testing.T) {
+	tree, _, err := KV{"db/host": "localhost", "hosts/1": "b", "hosts/0": "a", "ports/1": "1"}.DecodeTree()
+	if err != nil {
+		t.Fatal(err)
+	}
+	mp := tree.(map[string]interface{})
+	if mp["db"].(map[string]interface{})["host"] != "localhost" {
+		t.Fatalf("unexpected result: %v", tree)
+	}
+	if hosts, ok := mp["hosts"].([]interface{}); !ok || len(hosts) != 2 || hosts[0] != "a" {
+		t.Fatalf("unexpected result: %v", tree)
+	}
+	// index 0 isn't defined
+	if _, ok := mp["ports"].(map[string]interface{}); !ok {
+		t.Fatalf("unexpected result: %v", tree)
+	}
+}
+
+func TestKV_Conflict_Err(t *testing.T) {
+	_, _, err := KV{"db": "localhost", "db/host": "localhost"}.DecodeTree()
+	if err == nil || err.Error() != `key "db/host" conflicts with value of "db"` {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestSource_Parse_Ok(t *testing.T) {
+	kvs := newKVServer(map[string]string{
+		"service/billing/":                "",
+		"service/billing/db/host":         "db.internal",
+		"service/billing/db/port":         "5432",
+		"service/billing/hosts/0":         "a",
+		"service/billing/hosts/1":         "b",
+		"service/billing/request_timeout": "5s",
+		"service/other/db/host":           "other",
+	})
+	kvs.token = "secret"
+	srv := httptest.NewServer(kvs)
+	defer srv.Close()
+	var cfg billingConfig
+	err := envconf.Parse(&cfg, option.WithProvider(New(srv.URL, "service/billing/", WithToken("secret"))))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.DB.Host != "db.internal" || cfg.DB.Port != 5432 || cfg.Timeout != 5*time.Second ||
+		len(cfg.Hosts) != 2 || cfg.Hosts[1] != "b" {
+		t.Fatalf("unexpected result: %#v", cfg)
+	}
+}
+
+func TestSource_PrefixWithoutSlash_Ok(t *testing.T) {
+	srv := httptest.NewServer(newKVServer(map[string]string{
+		"service/billing/db/host":     "db.internal",
+		"service/billing-old/db/port": "5432",
+		"service/billingdb":           "old",
+	}))
+	defer srv.Close()
+	ext, err := New(srv.URL, "service/billing").Load(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if kv := ext.(KV); len(kv) != 1 || kv["db/host"] != "db.internal" {
+		t.Fatalf("unexpected keys: %v", kv)
+	}
+}
+
+// Common is embedded into configuration, its fields are promoted
+type Common struct {
+	Level string `default:"info"`
+	Debug bool
+}
+
+func TestSource_RenamedAndEmbedded_Ok(t *testing.T) {
+	srv := httptest.NewServer(newKVServer(map[string]string{
+		"service/billing/database/host": "db.internal",
+		"service/billing/level":         "debug",
+		"service/billing/common/level":  "warn",
+		"service/billing/common/debug":  "true",
+	}))
+	defer srv.Close()
+	var cfg struct {
+		Common
+		DB struct {
+			Host string `default:"localhost"`
+		} `envconf:"database"`
+	}
+	ec := envconf.New()
+	if err := ec.Parse(&cfg, option.WithProvider(New(srv.URL, "service/billing/"))); err != nil {
+		t.Fatal(err)
+	}
+	// promoted key overrides key of the embedded struct
+	if cfg.DB.Host != "db.internal" || cfg.Level != "debug" || !cfg.Debug {
+		t.Fatalf("unexpected result: %+v", cfg)
+	}
+	for _, name := range []string{"database.Host", "Common.Level", "Common.Debug"} {
+		if fr, _ := ec.Report().Field(name); fr.Source != option.ExternalSource {
+			t.Fatalf("unexpected report: %+v", fr)
+		}
+	}
+}
+
+func TestSource_PrefixNotFound_Ok(t *testing.T) {
+	srv := httptest.NewServer(newKVServer(map[string]string{}))
+	defer srv.Close()
+	ext, err := New(srv.URL, "service/billing").Load(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if kv := ext.(KV); len(kv) != 0 {
+		t.Fatalf("unexpected result: %v", kv)
+	}
+}
+
+func TestSource_Token_Err(t *testing.T) {
+	kvs := newKVServer(map[string]string{})
+	kvs.token = "secret"
+	srv := httptest.NewServer(kvs)
+	defer srv.Close()
+	var cfg billingConfig
+	err := envconf.Parse(&cfg, option.WithProvider(New(srv.URL, "service/billing/")))
+	var le *envconf.LoadError
+	if !errors.As(err, &le) || le.Source != srv.URL+"/service/billing/" {
+		t.Fatalf("expected LoadError but got %v", err)
+	}
+	if !strings.HasSuffix(err.Error(), "unexpected status: 403 Forbidden") {
+		t.Fatalf("unexpected error: %s", err)
+	}
+}
+
+func TestSource_ChangedWait_Ok(t *testing.T) {
+	srv := httptest.NewServer(newKVServer(map[string]string{"service/billing/db/host": "localhost"}))
+	defer srv.Close()
+	s := New(srv.URL, "service/billing/", WithWait(20*time.Millisecond))
+	if _, err := s.Load(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	start := time.Now()
+	changed, err := s.Changed(context.Background())
+	if err != nil || changed {
+		t.Fatalf("unexpected result: %t %v", changed, err)
+	}
+	if time.Since(start) < 20*time.Millisecond {
+		t.Fatal("query isn't blocked")
+	}
+	// keys outside of the prefix change index
+	put(t, srv.URL, "service/other/db/host", "other")
+	if changed, err := s.Changed(context.Background()); err != nil || changed {
+		t.Fatalf("unexpected result: %t %v", changed, err)
+	}
+}
+
+func TestSource_Watch_Ok(t *testing.T) {
+	srv := httptest.NewServer(newKVServer(map[string]string{"service/billing/db/port": "5432"}))
+	defer srv.Close()
+	var cfg billingConfig
+	ec := envconf.New()
+	if err := ec.Parse(&cfg, option.WithProvider(New(srv.URL, "service/billing/"))); err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	events, err := ec.Watch(ctx, envconf.WatchOptions{Interval: 5 * time.Millisecond})
+	if err != nil {
+		t.Fatal(err)
+	}
+	put(t, srv.URL, "service/billing/db/port", "6432")
+	var ev envconf.ReloadEvent
+	select {
+	case ev = <-events:
+	case <-time.After(5 * time.Second):
+		t.Fatal("reload event timeout")
+	}
+	if ev.Err != nil {
+		t.Fatal(ev.Err)
+	}
+	if next := ev.Config.(*billingConfig); next.DB.Port != 6432 || cfg.DB.Port != 5432 {
+		t.Fatalf("unexpected result: %#v", next)
+	}
+	if len(ev.Changes) != 1 || ev.Changes[0].FullName != "DB.Port" {
+		t.Fatalf("unexpected changes: %+v", ev.Changes)
+	}
+	cancel()
+	for range events {
+	}
+}
+
+func TestKV_Unmarshal_Err(t *testing.T) {
+	var cfg billingConfig
+	err := KV{"db/port": "abc"}.Unmarshal(&cfg)
+	if err == nil || !strings.HasPrefix(err.Error(), "db/port: ") {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := (KV{"labels/team": "billing"}).Unmarshal(&cfg); err != nil {
+		t.Fatal(err)
+	}
+	var mp map[string]interface{}
+	if err := (KV{"db/host": "localhost"}).Unmarshal(&mp); err != nil || mp["db"].(map[string]interface{})["host"] != "localhost" {
+		t.Fatalf("unexpected result: %v %v", mp, err)
+	}
+}
@@ -0,0 +1,106 @@
+package consul
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// KV is an External of key-value pairs. Keys are split by `/` into the path of the value,
+// e.g. `db/host` defines field DB.Host. Elements of arrays are keyed by index: `hosts/0`.
+// Path segments match field names case-insensitively, the `consul` tag of the field
+// or the `envconf` tag that renames struct in configuration names, e.g. `database/host` for
+// `DB struct{ Host string } envconf:"database"`. Fields of embedded structs are promoted:
+// `level` defines Common.Level of the embedded Common struct, as well as `common/level`.
+// Values are converted by type of the field: encoding.TextUnmarshaler, time.Duration and strings
+// are set as is, other types are decoded from JSON, e.g. `5432` or `true`
+type KV map[string]string
+
+// tagNames are tags of the field keys. envconf tag renames struct in configuration names,
+// so keys follow names of the fields
+var tagNames = []string{"consul", "envconf"}
+
+func (kv KV) TagName() []string {
+	return tagNames
+}
+
+// PromotesEmbedded returns true: fields of embedded structs are defined by keys of the outer struct
+func (kv KV) PromotesEmbedded() bool {
+	return true
+}
+
+// Unmarshal stores the values into v
+func (kv KV) Unmarshal(v interface{}) error {
+	_, assign, err := kv.DecodeTree()
+	if err != nil {
+		return err
+	}
+	return assign(v)
+}
+
+func (kv KV) DecodeTree() (interface{}, func(interface{}) error, error) {
+	tree, err := kv.tree()
+	if err != nil {
+		return nil, nil, err
+	}
+	return tree, func(v interface{}) error { return assign(v, tree) }, nil
+}
+
+func (kv KV) tree() (map[string]interface{}, error) {
+	keys := make([]string, 0, len(kv))
+	for k := range kv {
+		keys = append(keys, k)
+	}
+	// keys are sorted for deterministic conflict errors
+	sort.Strings(keys)
+	result := make(map[string]interface{})
+	for _, key := range keys {
+		path := strings.Split(strings.Trim(key, "/"), "/")
+		mp := result
+		for i, name := range path[:len(path)-1] {
+			switch vt := mp[name].(type) {
+			case nil:
+				next := make(map[string]interface{})
+				mp[name] = next
+				mp = next
+			case map[string]interface{}:
+				mp = vt
+			default:
+				return nil, fmt.Errorf("key %q conflicts with value of %q", key, strings.Join(path[:i+1], "/"))
+			}
+		}
+		name := path[len(path)-1]
+		if _, ok := mp[name]; ok {
+			return nil, fmt.Errorf("key %q conflicts with nested keys", key)
+		}
+		mp[name] = kv[key]
+	}
+	for k, v := range result {
+		result[k] = arrays(v)
+	}
+	return result, nil
+}
+
+// arrays replaces maps keyed by consecutive indexes with arrays
+func arrays(v interface{}) interface{} {
+	mp, ok := v.(map[string]interface{})
+	if !ok {
+		return v
+	}
+	for k, v := range mp {
+		mp[k] = arrays(v)
+	}
+	if len(mp) == 0 {
+		return mp
+	}
+	sl := make([]interface{}, len(mp))
+	for k, v := range mp {
+		idx, err := strconv.Atoi(k)
+		if err != nil || idx < 0 || idx >= len(mp) || strconv.Itoa(idx) != k {
+			return mp
+		}
+		sl[idx] = v
+	}
+	return sl
+}
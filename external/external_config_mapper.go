@@ -5,7 +5,6 @@ import (
 	"reflect"
 	"strconv"
 	"strings"
-	"unicode"
 )
 
 type ExternalSource interface {
@@ -46,19 +45,73 @@ func AsExternalSource(name string, es ExternalSource) ExternalSource {
 	if !ok {
 		return NilContainer{}
 	}
+	var result ExternalSource
 	switch vt := v.(type) {
 	case map[string]interface{}:
-		return mapContainer(vt)
+		result = mapContainer(vt)
 	case []interface{}:
-		return sliceContainer(vt)
+		result = sliceContainer(vt)
 	default:
 		return NilContainer{}
 	}
+	if pc, ok := es.(positionContainer); ok {
+		if pos := pc.pos.Child(name); pos != nil {
+			return positionContainer{ExternalSource: result, pos: pos}
+		}
+	}
+	return result
+}
+
+// UnknownKey is a key of external source that doesn't match any struct field
+type UnknownKey struct {
+	// Path is a list of parent keys
+	Path []string
+	Key  string
+	// Known keys that are expected next to the unknown one
+	Known []string
+	// Position of the key value. Invalid if external doesn't report positions
+	Position Position
+}
+
+// FullPath returns dot separated path of the key
+func (k UnknownKey) FullPath() string {
+	return strings.Join(append(append([]string{}, k.Path...), k.Key), ".")
+}
+
+// MappingError is returned when external data cannot be mapped into the struct
+type MappingError struct {
+	// Path is a list of keys to the value. Empty for errors of the entire source
+	Path []string
+	Err  error
+	// Position of the value. Invalid if external doesn't report positions
+	Position Position
+}
+
+func (e *MappingError) Error() string {
+	msg := e.Err.Error()
+	if len(e.Path) != 0 {
+		msg = fmt.Sprintf("%s: %s", strings.Join(e.Path, "."), msg)
+	}
+	if e.Position.IsValid() {
+		msg += fmt.Sprintf(" (%s)", e.Position)
+	}
+	return msg
+}
+
+func (e *MappingError) Unwrap() error {
+	return e.Err
 }
 
 type ExternalConfigMapper struct {
 	ext  External
 	data map[string]interface{}
+	pos  *PositionTree
+	// position returns position of the value by path of the source keys. nil if external doesn't report positions
+	position func([]string) (Position, bool)
+	validate func(interface{}) error
+	unknown  []UnknownKey
+	// promote is true if external defines fields of embedded structs by keys of the outer struct
+	promote bool
 }
 
 func NewExternalConfigMapper(ext External) *ExternalConfigMapper {
@@ -69,127 +122,227 @@ func NewExternalConfigMapper(ext External) *ExternalConfigMapper {
 }
 
 func (c *ExternalConfigMapper) Data() ExternalSource {
+	if c.pos != nil {
+		return positionContainer{ExternalSource: mapContainer(c.data), pos: c.pos}
+	}
 	return mapContainer(c.data)
 }
 
+// UnknownKeys returns keys of external source that didn't match any field
+func (c *ExternalConfigMapper) UnknownKeys() []UnknownKey {
+	return c.unknown
+}
+
+// SetValidator sets function that validates decoded external data before mapping
+func (c *ExternalConfigMapper) SetValidator(f func(interface{}) error) {
+	c.validate = f
+}
+
 func (c *ExternalConfigMapper) Unmarshal(v interface{}) error {
 	if c.ext == nil {
 		return nil
 	}
-	err := c.ext.Unmarshal(v)
+	tree, position, assign, err := decode(c.ext)
 	if err != nil {
-		return err
+		return &MappingError{Err: err}
 	}
-	mp := make(map[string]interface{})
-	err = c.ext.Unmarshal(&mp)
-	if err != nil {
-		return err
+	c.position = position
+	c.promote = promotes(c.ext)
+	mp, ok := tree.(map[string]interface{})
+	if !ok && tree != nil {
+		return &MappingError{Err: fmt.Errorf("unable to cast %T into map[string]interface{}", tree)}
+	}
+	if mp == nil {
+		mp = make(map[string]interface{})
+	}
+	if c.validate != nil {
+		if err = c.validate(mp); err != nil {
+			return err
+		}
 	}
 	rv := reflect.ValueOf(v)
 	if rv.Kind() == reflect.Pointer {
 		rv = rv.Elem()
 	}
-	c.data, err = c.normalizeMap(rv, mp)
+	c.unknown = nil
+	aerr := assign(v)
+	// on failure of assign it's looking for the key that doesn't match the struct
+	c.data, c.pos, err = c.normalizeMap(rv, mp, nil)
 	if err != nil {
 		return err
 	}
+	if aerr != nil {
+		// data is kept, so caller can find values of the source that failed to map
+		return &MappingError{Err: aerr}
+	}
 	return nil
 }
 
-func (c *ExternalConfigMapper) normalizeMap(rv reflect.Value, mp map[string]interface{}) (map[string]interface{}, error) {
+// normalizeMap renames keys of mp into names of struct fields.
+// Returned positions are keyed by the same names
+func (c *ExternalConfigMapper) normalizeMap(rv reflect.Value, mp map[string]interface{}, path []string) (map[string]interface{}, *PositionTree, error) {
 	result := make(map[string]interface{})
+	pos := c.positionOf(path, nil)
+	var promoted []string
 	for k, v := range mp {
-		var fr rune
-		for _, r := range k {
-			fr = r
-			break
-		}
-		lc := unicode.IsLower(fr)
+		matched := false
 		// normalizing names(keys) in map
 		rt := rv.Type()
 		for i := 0; i < rv.NumField(); i++ {
 			sf := rt.Field(i)
 			f := rv.Field(i)
-			if !c.equal(k, lc, sf) {
+			if !MatchKey(k, sf, c.ext.TagName()) {
 				continue
 			}
-			val, err := c.normalize(f, v)
+			val, vpos, err := c.normalize(f, v, append(path[:len(path):len(path)], k))
 			if err != nil {
-				return nil, err
+				return nil, nil, err
 			}
 			result[sf.Name] = val
+			pos.add(sf.Name, vpos)
+			matched = true
 			break
 		}
+		if !matched && c.promote && PromotedIndex(rt, k, c.ext.TagName()) != nil {
+			promoted = append(promoted, k)
+			matched = true
+		}
+		if !matched {
+			uk := UnknownKey{
+				Path:  path,
+				Key:   k,
+				Known: c.keys(rv.Type()),
+			}
+			if kpos := c.positionOf(append(path[:len(path):len(path)], k), nil); kpos != nil {
+				uk.Position = kpos.Position
+			}
+			c.unknown = append(c.unknown, uk)
+		}
+	}
+	// promoted fields override values of the embedded structs defined by their names
+	for _, k := range promoted {
+		if err := c.normalizePromoted(rv, result, pos, mp[k], append(path[:len(path):len(path)], k)); err != nil {
+			return nil, nil, err
+		}
 	}
-	return result, nil
+	return result, pos, nil
 }
 
-func (c *ExternalConfigMapper) normalizeSlice(rv reflect.Value, sl []interface{}) ([]interface{}, error) {
+// normalizePromoted stores value v of the promoted field into result under names of the embedded structs
+func (c *ExternalConfigMapper) normalizePromoted(rv reflect.Value, result map[string]interface{}, pos *PositionTree, v interface{}, path []string) error {
+	rt := rv.Type()
+	index := PromotedIndex(rt, path[len(path)-1], c.ext.TagName())
+	for _, i := range index[:len(index)-1] {
+		name := rt.Field(i).Name
+		next, ok := result[name].(map[string]interface{})
+		if !ok {
+			next = make(map[string]interface{})
+			result[name] = next
+		}
+		result, rt, pos = next, rt.Field(i).Type, pos.with(name)
+	}
+	val, vpos, err := c.normalize(rv.FieldByIndex(index), v, path)
+	if err != nil {
+		return err
+	}
+	name := rt.Field(index[len(index)-1]).Name
+	result[name] = val
+	pos.add(name, vpos)
+	return nil
+}
+
+// keys returns names of struct fields in the external source
+func (c *ExternalConfigMapper) keys(rt reflect.Type) []string {
+	result := make([]string, 0, rt.NumField())
+	for i := 0; i < rt.NumField(); i++ {
+		result = append(result, FieldKey(rt.Field(i), c.ext.TagName()))
+	}
+	return result
+}
+
+func (c *ExternalConfigMapper) normalizeSlice(rv reflect.Value, sl []interface{}, path []string) ([]interface{}, *PositionTree, error) {
+	pos := c.positionOf(path, nil)
 	for i := range sl {
-		v, err := c.normalize(rv.Index(i), sl[i])
+		idx := strconv.Itoa(i)
+		if i >= rv.Len() {
+			// element wasn't decoded into the value (fixed size array or failed decoding)
+			pos.add(idx, c.positionOf(append(path[:len(path):len(path)], idx), sl[i]))
+			continue
+		}
+		v, vpos, err := c.normalize(rv.Index(i), sl[i], append(path[:len(path):len(path)], idx))
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 		sl[i] = v
+		pos.add(idx, vpos)
 	}
-	return sl, nil
+	return sl, pos, nil
 }
 
-func (c *ExternalConfigMapper) normalize(rv reflect.Value, v interface{}) (interface{}, error) {
+func (c *ExternalConfigMapper) normalize(rv reflect.Value, v interface{}, path []string) (interface{}, *PositionTree, error) {
 	switch vt := v.(type) {
 	case map[string]interface{}:
 		switch rv.Kind() {
 		case reflect.Map:
-			return vt, nil
+			return vt, c.positionOf(path, vt), nil
 		case reflect.Struct:
-			return c.normalizeMap(rv, vt)
+			return c.normalizeMap(rv, vt, path)
 		case reflect.Interface:
 			if rv.IsValid() && !rv.IsZero() {
-				return c.normalize(rv.Elem(), v)
+				return c.normalize(rv.Elem(), v, path)
 			}
-			return vt, nil
+			return vt, c.positionOf(path, vt), nil
 		case reflect.Pointer:
 			if rv.IsValid() && !rv.IsZero() {
-				return c.normalize(rv.Elem(), v)
+				return c.normalize(rv.Elem(), v, path)
 			}
-			return vt, nil
+			return vt, c.positionOf(path, vt), nil
 		default:
-			return nil, fmt.Errorf("unable to cast map[string]interface{} into %s", rv.Type().Name())
+			return nil, nil, c.mappingError(path,
+				fmt.Errorf("unable to cast map[string]interface{} into %s", rv.Type().Name()))
 		}
 	case []interface{}:
 		switch rv.Kind() {
 		case reflect.Slice, reflect.Array:
-			return c.normalizeSlice(rv, vt)
+			return c.normalizeSlice(rv, vt, path)
 		default:
-			return nil, fmt.Errorf("unable to cast []interface{} into %s", rv.Type().String())
+			return nil, nil, c.mappingError(path,
+				fmt.Errorf("unable to cast []interface{} into %s", rv.Type().String()))
 		}
 	default:
-		return vt, nil
+		return vt, c.positionOf(path, nil), nil
 	}
 }
 
-func (c *ExternalConfigMapper) equal(key string, lc bool, sf reflect.StructField) bool {
-	for _, tagName := range c.ext.TagName() {
-		tag, ok := sf.Tag.Lookup(tagName)
-		if ok {
-			idx := strings.IndexRune(tag, ',')
-			if idx != -1 {
-				tag = tag[:idx]
-			}
-			if key == tag {
-				return true
-			}
-		}
+func (c *ExternalConfigMapper) mappingError(path []string, err error) *MappingError {
+	me := &MappingError{Path: path, Err: err}
+	if pos := c.positionOf(path, nil); pos != nil {
+		me.Position = pos.Position
 	}
+	return me
+}
 
-	// unexportable field. looking for any first match with EqualFold
-	if lc && strings.EqualFold(key, sf.Name) {
-		return true
+// positionOf returns positions of the value v by path of the source keys.
+// Positions of nested values of v are added as is. Returns nil if position is unknown
+func (c *ExternalConfigMapper) positionOf(path []string, v interface{}) *PositionTree {
+	if c.position == nil {
+		return nil
 	}
-
-	if key == sf.Name {
-		return true
+	pos, ok := c.position(path)
+	if !ok {
+		return nil
 	}
-
-	return false
+	result := &PositionTree{Position: pos}
+	switch vt := v.(type) {
+	case map[string]interface{}:
+		for k, v := range vt {
+			result.add(k, c.positionOf(append(path[:len(path):len(path)], k), v))
+		}
+	case []interface{}:
+		for i, v := range vt {
+			idx := strconv.Itoa(i)
+			result.add(idx, c.positionOf(append(path[:len(path):len(path)], idx), v))
+		}
+	}
+	return result
 }
@@ -71,3 +71,171 @@ func TestExternalConfigMapper_InvalidJson_Ok(t *testing.T) {
 		t.Fatal("expected error but got nil")
 	}
 }
+
+// countingExternal implements only External, so it is adapted by AsTreeDecoder
+type countingExternal struct {
+	data      json.Json
+	unmarshal int
+	decode    int
+}
+
+func (c *countingExternal) TagName() []string {
+	return c.data.TagName()
+}
+
+func (c *countingExternal) Unmarshal(v interface{}) error {
+	c.unmarshal++
+	return c.data.Unmarshal(v)
+}
+
+type countingTreeDecoder struct {
+	countingExternal
+}
+
+func (c *countingTreeDecoder) DecodeTree() (interface{}, func(interface{}) error, error) {
+	c.decode++
+	return c.data.DecodeTree()
+}
+
+func TestExternalConfigMapper_TreeDecoder_DecodedOnce_Ok(t *testing.T) {
+	ext := &countingTreeDecoder{countingExternal{data: json.Json(`{"foo": "bar"}`)}}
+	extMp := NewExternalConfigMapper(ext)
+	result := struct{ Foo string }{}
+	if err := extMp.Unmarshal(&result); err != nil {
+		t.Fatal("mapper.Unmarshal: ", err)
+	}
+	if ext.decode != 1 || ext.unmarshal != 0 {
+		t.Fatalf("unexpected calls: decode=%d unmarshal=%d", ext.decode, ext.unmarshal)
+	}
+	if result.Foo != "bar" || extMp.Data().(mapContainer)["Foo"] != "bar" {
+		t.Fatalf("unexpected result: %#v", result)
+	}
+}
+
+func TestExternalConfigMapper_UnmarshalAdapter_Ok(t *testing.T) {
+	ext := &countingExternal{data: json.Json(`{"foo": "bar"}`)}
+	extMp := NewExternalConfigMapper(ext)
+	result := struct{ Foo string }{}
+	if err := extMp.Unmarshal(&result); err != nil {
+		t.Fatal("mapper.Unmarshal: ", err)
+	}
+	if ext.unmarshal != 2 {
+		t.Fatalf("unexpected unmarshal calls: %d", ext.unmarshal)
+	}
+	if result.Foo != "bar" || extMp.Data().(mapContainer)["Foo"] != "bar" {
+		t.Fatalf("unexpected result: %#v", result)
+	}
+}
+
+func TestExternalConfigMapper_TreeNotObject_Err(t *testing.T) {
+	extMp := NewExternalConfigMapper(json.Json(`[1, 2]`))
+	if err := extMp.Unmarshal(&struct{}{}); err == nil {
+		t.Fatal("expected error but got nil")
+	}
+}
+
+func TestExternalConfigMapper_Positions_Ok(t *testing.T) {
+	const data = `{
+	"db": {"host": "db", "tags": ["a"]},
+	"options": {"key": 1}
+}`
+	extMp := NewExternalConfigMapper(WithPath(json.Json(data), "config.json"))
+	result := struct {
+		DB struct {
+			Host string   `json:"host"`
+			Tags []string `json:"tags"`
+		} `json:"db"`
+		Options map[string]int `json:"options"`
+	}{}
+	if err := extMp.Unmarshal(&result); err != nil {
+		t.Fatal("mapper.Unmarshal: ", err)
+	}
+	db := AsExternalSource("DB", extMp.Data())
+	cases := []struct {
+		es       ExternalSource
+		key, pos string
+	}{
+		{extMp.Data(), "DB", "config.json:2:8"},
+		{db, "Host", "config.json:2:17"},
+		{AsExternalSource("Tags", db), "0", "config.json:2:32"},
+		{AsExternalSource("Options", extMp.Data()), "key", "config.json:3:21"},
+	}
+	for _, c := range cases {
+		pos, ok := PositionOf(c.es, c.key)
+		if !ok || pos.String() != c.pos {
+			t.Fatalf("%s: expected position %s but got %s", c.key, c.pos, pos)
+		}
+	}
+	if _, ok := PositionOf(db, "Port"); ok {
+		t.Fatal("unexpected position of unknown key")
+	}
+}
+
+func TestMerge_Ok(t *testing.T) {
+	ext := Merge(json.Json(`{"db": {"host": "a", "port": 1}, "name": "a"}`), WithPath(json.Json(`{"db": {"host": "b"}}`), "b.json"))
+	tree, pos, _, err := decode(ext)
+	if err != nil {
+		t.Fatal(err)
+	}
+	db := tree.(map[string]interface{})["db"].(map[string]interface{})
+	if db["host"] != "b" || db["port"] != float64(1) {
+		t.Fatalf("unexpected result: %v", tree)
+	}
+	if p, ok := pos([]string{"db", "host"}); !ok || p.String() != "b.json:1:17" {
+		t.Fatalf("unexpected position: %s", p)
+	}
+	if p, ok := pos([]string{"name"}); !ok || p.String() != "1:42" {
+		t.Fatalf("unexpected position: %s", p)
+	}
+}
+
+// promotingJson is json that reports promotion of embedded fields, as encoding/json does it
+type promotingJson struct {
+	json.Json
+}
+
+func (promotingJson) PromotesEmbedded() bool {
+	return true
+}
+
+type Common struct {
+	Level string `json:"level"`
+}
+
+func TestExternalConfigMapper_Promoted_Ok(t *testing.T) {
+	extMp := NewExternalConfigMapper(WithPath(promotingJson{json.Json(`{"name": "a", "level": "debug"}`)}, "config.json"))
+	result := struct {
+		Common
+		Name string `json:"name"`
+	}{}
+	if err := extMp.Unmarshal(&result); err != nil {
+		t.Fatal("mapper.Unmarshal: ", err)
+	}
+	common := AsExternalSource("Common", extMp.Data())
+	if v, ok := common.Read("Level"); !ok || v != "debug" || result.Level != "debug" {
+		t.Fatalf("unexpected result: %v %#v", v, result)
+	}
+	if pos, ok := PositionOf(common, "Level"); !ok || pos.String() != "config.json:1:24" {
+		t.Fatalf("unexpected position: %s", pos)
+	}
+	if len(extMp.UnknownKeys()) != 0 {
+		t.Fatalf("unexpected unknown keys: %v", extMp.UnknownKeys())
+	}
+}
+
+func TestExternalConfigMapper_NotPromoted_Ok(t *testing.T) {
+	// merged externals promote fields only if every external does it
+	extMp := NewExternalConfigMapper(Merge(promotingJson{json.Json(`{"level": "debug"}`)}, json.Json(`{}`)))
+	result := struct {
+		Common
+	}{}
+	if err := extMp.Unmarshal(&result); err != nil {
+		t.Fatal("mapper.Unmarshal: ", err)
+	}
+	if _, ok := extMp.Data().Read("Common"); ok {
+		t.Fatal("unexpected value of embedded struct")
+	}
+	if uk := extMp.UnknownKeys(); len(uk) != 1 || uk[0].Key != "level" {
+		t.Fatalf("unexpected unknown keys: %v", uk)
+	}
+}
@@ -11,6 +11,7 @@ import (
 
 	"github.com/antonmashko/envconf"
 	jsonconf "github.com/antonmashko/envconf/external/json"
+	"github.com/antonmashko/envconf/jsonschema"
 	"github.com/antonmashko/envconf/option"
 )
 
@@ -552,3 +553,101 @@ func TestJsonConfig_InterfaceInitInFlagParsed_Ok(t *testing.T) {
 		t.Fatalf("incorrect result: expected=%s actual=%s", expectedFooBar, inner.Bar)
 	}
 }
+
+func TestJsonConfig_SchemaValidation_Err(t *testing.T) {
+	json := `{"foo": "bar", "inner": {"port": "abc"}, "hosts": [1]}`
+	tc := struct {
+		Foo   int `json:"foo" default:"1"`
+		Inner struct {
+			Port int `json:"port" required:"true" default:"1"`
+		} `json:"inner"`
+		Hosts []string `json:"hosts"`
+	}{}
+	err := envconf.Parse(&tc,
+		option.WithExternal(jsonconf.Json([]byte(json))),
+		option.WithSchemaValidation(nil),
+	)
+	var vs jsonschema.Violations
+	if !errors.As(err, &vs) {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(vs) != 3 || vs.Error() != "schema validation failed: "+
+		"/foo: expected integer but got string; /hosts/0: expected string but got integer; /inner/port: expected integer but got string" {
+		t.Fatalf("unexpected violations: %s", vs)
+	}
+}
+
+func TestJsonConfig_SchemaValidationCustomSchema_Ok(t *testing.T) {
+	json := `{"foo": 1}`
+	tc := struct {
+		Foo int `json:"foo"`
+	}{}
+	s, err := jsonschema.Parse([]byte(`{"type":"object","properties":{"foo":{"type":"integer","maximum":0}}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = envconf.Parse(&tc,
+		option.WithExternal(jsonconf.Json([]byte(json))),
+		option.WithSchemaValidation(s),
+	)
+	if err == nil {
+		t.Fatal("expected error but got nil")
+	}
+	err = envconf.Parse(&tc,
+		option.WithExternal(jsonconf.Json([]byte(`{"foo": -1}`))),
+		option.WithSchemaValidation(s),
+	)
+	if err != nil || tc.Foo != -1 {
+		t.Fatalf("unexpected result: %v %#v", err, tc)
+	}
+}
+
+func TestJsonConfig_StrictExternal_Err(t *testing.T) {
+	json := `{"tiemout": "1s", "db": {"hots": "localhost", "port": 5432}, "servers": [{"nmae": "a"}]}`
+	tc := struct {
+		Timeout string
+		DB      struct {
+			Host string `json:"host"`
+			Port int    `json:"port"`
+		} `json:"db"`
+		Servers []struct {
+			Name string `json:"name"`
+		} `json:"servers"`
+	}{}
+	err := envconf.Parse(&tc,
+		option.WithExternal(jsonconf.Json([]byte(json))),
+		option.WithStrictExternal(),
+	)
+	if err == nil {
+		t.Fatal("expected error but got nil")
+	}
+	const expected = `unknown keys in External source: db.hots (did you mean "host"?), ` +
+		`servers.0.nmae (did you mean "name"?), tiemout (did you mean "Timeout"?)`
+	if err.Error() != expected {
+		t.Fatalf("unexpected error.\nexpected=%s\nactual=  %s", expected, err)
+	}
+}
+
+func TestJsonConfig_UnknownKeyHook_Ok(t *testing.T) {
+	json := `{"foo": "bar", "completely_different": 1}`
+	tc := struct {
+		Foo string `json:"foo"`
+	}{}
+	var keys []option.UnknownKeyArg
+	err := envconf.Parse(&tc,
+		option.WithExternal(jsonconf.Json([]byte(json))),
+		option.WithUnknownKeyHook(func(arg option.UnknownKeyArg) {
+			keys = append(keys, arg)
+		}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tc.Foo != "bar" {
+		t.Fatalf("unexpected result: %#v", tc)
+	}
+	if len(keys) != 1 || keys[0].Key != "completely_different" ||
+		keys[0].Suggestion != "" || keys[0].Source != option.ExternalSource {
+		t.Fatalf("unexpected unknown keys: %#v", keys)
+	}
+}
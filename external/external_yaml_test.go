@@ -0,0 +1,66 @@
+package external_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/antonmashko/envconf"
+	"github.com/antonmashko/envconf/external"
+	"github.com/antonmashko/envconf/option"
+	"gopkg.in/yaml.v3"
+)
+
+// yamlConf is the same as Yaml of external/yaml module which cannot be imported here
+type yamlConf []byte
+
+func (y yamlConf) TagName() []string {
+	return []string{"yaml"}
+}
+
+func (y yamlConf) Unmarshal(v interface{}) error {
+	return yaml.Unmarshal(y, v)
+}
+
+func TestYamlConfig_InvalidSliceElement_Err(t *testing.T) {
+	data := "pass: [1, x]\n"
+	tc := struct {
+		Pass []int `yaml:"pass"`
+	}{}
+	err := envconf.Parse(&tc, option.WithExternal(yamlConf(data)))
+	var me *external.MappingError
+	if !errors.As(err, &me) {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestYamlConfig_SecretValueInError_Ok(t *testing.T) {
+	data := "db:\n  pass: hunter2\n"
+	tc := struct {
+		DB struct {
+			Pass int `yaml:"pass" secret:"true"`
+		} `yaml:"db"`
+	}{}
+	err := envconf.Parse(&tc, option.WithExternal(yamlConf(data)))
+	if err == nil || strings.Contains(err.Error(), "hunter2") {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestYamlConfig_DurationSchemaValidation_Ok(t *testing.T) {
+	data := "timeout: 5s\n"
+	tc := struct {
+		Timeout time.Duration `yaml:"timeout"`
+	}{}
+	err := envconf.Parse(&tc,
+		option.WithExternal(yamlConf(data)),
+		option.WithSchemaValidation(nil),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tc.Timeout != 5*time.Second {
+		t.Fatalf("unexpected value: %s", tc.Timeout)
+	}
+}
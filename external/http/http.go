@@ -0,0 +1,211 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sync"
+
+	"github.com/antonmashko/envconf/external"
+)
+
+// Source loads external data from HTTP endpoint.
+// Source implements external.Provider and external.Watcher, so it can be passed to option.WithProvider
+// and polled by EnvConf.Watch. Requests after the first successful load are conditional (If-None-Match),
+// unchanged data isn't transferred again
+type Source struct {
+	url    string
+	decode func([]byte) (external.External, error)
+	header http.Header
+	token  string
+	tls    *tls.Config
+	client *http.Client
+	cache  string
+
+	mu sync.Mutex
+	// data, etag and path of the last loaded data
+	data []byte
+	etag string
+	path string
+	// changed is the response received by Changed. It's used by the following Load instead of the new request
+	changed *response
+}
+
+// Option configures Source
+type Option func(*Source)
+
+// WithHeader adds header to the requests
+func WithHeader(key, value string) Option {
+	return func(s *Source) {
+		s.header.Add(key, value)
+	}
+}
+
+// WithBearerToken adds Authorization header with bearer token to the requests
+func WithBearerToken(token string) Option {
+	return func(s *Source) {
+		s.token = token
+	}
+}
+
+// WithTLSConfig sets TLS configuration of the client. Ignored with WithClient
+func WithTLSConfig(c *tls.Config) Option {
+	return func(s *Source) {
+		s.tls = c
+	}
+}
+
+// WithClient sets HTTP client of the requests. Default: client with default transport
+func WithClient(c *http.Client) Option {
+	return func(s *Source) {
+		s.client = c
+	}
+}
+
+// WithCache saves loaded data into the file. If the first load fails, data is read from the file instead
+func WithCache(path string) Option {
+	return func(s *Source) {
+		s.cache = path
+	}
+}
+
+// New returns Source that requests url and decodes response body with decode, e.g.
+//
+//	func(b []byte) (external.External, error) { return json.Json(b), nil }
+func New(url string, decode func([]byte) (external.External, error), opts ...Option) *Source {
+	s := &Source{
+		url:    url,
+		decode: decode,
+		header: make(http.Header),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	if s.client == nil {
+		s.client = &http.Client{}
+		if s.tls != nil {
+			t := http.DefaultTransport.(*http.Transport).Clone()
+			t.TLSClientConfig = s.tls
+			s.client.Transport = t
+		}
+	}
+	return s
+}
+
+// Name returns url of the source
+func (s *Source) Name() string {
+	return s.url
+}
+
+// Load requests data from url. Previously loaded data is returned if it isn't modified
+func (s *Source) Load(ctx context.Context) (external.External, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var res response
+	if s.changed != nil {
+		res, s.changed = *s.changed, nil
+	} else {
+		var err error
+		if res, err = s.fetch(ctx); err != nil {
+			return s.fallback(err)
+		}
+	}
+	if res.notModified {
+		return s.external(s.data, s.path)
+	}
+	ext, err := s.external(res.body, s.url)
+	if err != nil {
+		return nil, err
+	}
+	if s.cache != "" && !bytes.Equal(res.body, s.data) {
+		if err := os.WriteFile(s.cache, res.body, 0o600); err != nil {
+			return nil, fmt.Errorf("unable to write cache: %w", err)
+		}
+	}
+	s.data, s.etag, s.path = res.body, res.etag, s.url
+	return ext, nil
+}
+
+// Changed requests data from url and reports whether it differs from the last loaded data.
+// Changed data is kept for the following Load, so it isn't requested again
+func (s *Source) Changed(ctx context.Context) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	res, err := s.fetch(ctx)
+	if err != nil {
+		return false, err
+	}
+	// server may not support ETag
+	if res.notModified || bytes.Equal(res.body, s.data) {
+		return false, nil
+	}
+	s.changed = &res
+	return true, nil
+}
+
+// fallback reads data from the cache if nothing was loaded before. Otherwise err is returned
+func (s *Source) fallback(err error) (external.External, error) {
+	if s.cache == "" || s.data != nil {
+		return nil, err
+	}
+	b, cerr := os.ReadFile(s.cache)
+	if cerr != nil {
+		return nil, err
+	}
+	ext, cerr := s.external(b, s.cache)
+	if cerr != nil {
+		return nil, err
+	}
+	s.data, s.path = b, s.cache
+	return ext, nil
+}
+
+func (s *Source) external(b []byte, path string) (external.External, error) {
+	ext, err := s.decode(b)
+	if err != nil {
+		return nil, err
+	}
+	return external.WithPath(ext, path), nil
+}
+
+type response struct {
+	body        []byte
+	etag        string
+	notModified bool
+}
+
+func (s *Source) fetch(ctx context.Context) (response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.url, nil)
+	if err != nil {
+		return response{}, err
+	}
+	for k, v := range s.header {
+		req.Header[k] = v
+	}
+	if s.token != "" {
+		req.Header.Set("Authorization", "Bearer "+s.token)
+	}
+	if s.etag != "" {
+		req.Header.Set("If-None-Match", s.etag)
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return response{}, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotModified && s.etag != "" {
+		return response{etag: s.etag, notModified: true}, nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return response{}, fmt.Errorf("unexpected status: %s", resp.Status)
+	}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return response{}, err
+	}
+	return response{body: body, etag: resp.Header.Get("ETag")}, nil
+}
@@ -0,0 +1,195 @@
+package http
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/antonmashko/envconf/external"
+	"github.com/antonmashko/envconf/external/json"
+)
+
+func decodeJson(b []byte) (external.External, error) {
+	return json.Json(b), nil
+}
+
+// configServer serves data with ETag and counts full responses
+type configServer struct {
+	mu   sync.Mutex
+	data string
+	etag string
+	sent int
+}
+
+func (s *configServer) set(data, etag string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.data, s.etag = data, etag
+}
+
+func (s *configServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.etag != "" && r.Header.Get("If-None-Match") == s.etag {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	s.sent++
+	if s.etag != "" {
+		w.Header().Set("ETag", s.etag)
+	}
+	w.Write([]byte(s.data))
+}
+
+func load(t *testing.T, s *Source) map[string]interface{} {
+	t.Helper()
+	ext, err := s.Load(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var result map[string]interface{}
+	if err := ext.Unmarshal(&result); err != nil {
+		t.Fatal(err)
+	}
+	return result
+}
+
+func TestSource_Load_Ok(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token" || r.Header.Get("X-Env") != "prod" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Write([]byte(`{"addr": "localhost"}`))
+	}))
+	defer srv.Close()
+	s := New(srv.URL, decodeJson, WithBearerToken("token"), WithHeader("X-Env", "prod"))
+	if s.Name() != srv.URL {
+		t.Fatalf("unexpected name: %s", s.Name())
+	}
+	ext, err := s.Load(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ext.TagName()[0] != "json" {
+		t.Fatalf("unexpected tag name: %v", ext.TagName())
+	}
+	if result := load(t, s); result["addr"] != "localhost" {
+		t.Fatalf("unexpected result: %v", result)
+	}
+}
+
+func TestSource_ETag_Ok(t *testing.T) {
+	cs := &configServer{data: `{"port": 80}`, etag: `"v1"`}
+	srv := httptest.NewServer(cs)
+	defer srv.Close()
+	s := New(srv.URL, decodeJson)
+	load(t, s)
+	changed, err := s.Changed(context.Background())
+	if err != nil || changed {
+		t.Fatalf("unexpected result: %t %v", changed, err)
+	}
+	if result := load(t, s); result["port"] != float64(80) {
+		t.Fatalf("unexpected result: %v", result)
+	}
+	if cs.sent != 1 {
+		t.Fatalf("expected data to be sent once but got %d", cs.sent)
+	}
+	cs.set(`{"port": 8080}`, `"v2"`)
+	changed, err = s.Changed(context.Background())
+	if err != nil || !changed {
+		t.Fatalf("unexpected result: %t %v", changed, err)
+	}
+	if result := load(t, s); result["port"] != float64(8080) {
+		t.Fatalf("unexpected result: %v", result)
+	}
+	// data received by Changed is loaded without the new request
+	if cs.sent != 2 {
+		t.Fatalf("expected data to be sent twice but got %d", cs.sent)
+	}
+}
+
+func TestSource_WithoutETag_Ok(t *testing.T) {
+	cs := &configServer{data: `{"port": 80}`}
+	srv := httptest.NewServer(cs)
+	defer srv.Close()
+	s := New(srv.URL, decodeJson)
+	load(t, s)
+	if changed, err := s.Changed(context.Background()); err != nil || changed {
+		t.Fatalf("unexpected result: %t %v", changed, err)
+	}
+	cs.set(`{"port": 8080}`, "")
+	if changed, err := s.Changed(context.Background()); err != nil || !changed {
+		t.Fatalf("unexpected result: %t %v", changed, err)
+	}
+	if result := load(t, s); result["port"] != float64(8080) || cs.sent != 3 {
+		t.Fatalf("unexpected result: %v, sent %d", result, cs.sent)
+	}
+}
+
+func TestSource_TLSConfig_Ok(t *testing.T) {
+	srv := httptest.NewTLSServer(&configServer{data: `{"addr": "localhost"}`})
+	defer srv.Close()
+	if _, err := New(srv.URL, decodeJson).Load(context.Background()); err == nil {
+		t.Fatal("expected certificate error but got nil")
+	}
+	pool := x509.NewCertPool()
+	pool.AddCert(srv.Certificate())
+	s := New(srv.URL, decodeJson, WithTLSConfig(&tls.Config{RootCAs: pool}))
+	if result := load(t, s); result["addr"] != "localhost" {
+		t.Fatalf("unexpected result: %v", result)
+	}
+}
+
+func TestSource_Status_Err(t *testing.T) {
+	srv := httptest.NewServer(http.NotFoundHandler())
+	defer srv.Close()
+	_, err := New(srv.URL, decodeJson).Load(context.Background())
+	if err == nil || err.Error() != "unexpected status: 404 Not Found" {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestSource_CacheFallback_Ok(t *testing.T) {
+	cache := filepath.Join(t.TempDir(), "config.json")
+	srv := httptest.NewServer(&configServer{data: `{"addr": "localhost"}`})
+	load(t, New(srv.URL, decodeJson, WithCache(cache)))
+	srv.Close()
+
+	b, err := os.ReadFile(cache)
+	if err != nil || string(b) != `{"addr": "localhost"}` {
+		t.Fatalf("unexpected cache: %s %v", b, err)
+	}
+	if _, err := New(srv.URL, decodeJson).Load(context.Background()); err == nil {
+		t.Fatal("expected error but got nil")
+	}
+	s := New(srv.URL, decodeJson, WithCache(cache))
+	ext, err := s.Load(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var result map[string]interface{}
+	if err := ext.Unmarshal(&result); err != nil || result["addr"] != "localhost" {
+		t.Fatalf("unexpected result: %v %v", result, err)
+	}
+	// cache is used only if nothing was loaded
+	if _, err := s.Load(context.Background()); err == nil {
+		t.Fatal("expected error but got nil")
+	}
+}
+
+func TestSource_CacheMissing_Err(t *testing.T) {
+	srv := httptest.NewServer(http.NotFoundHandler())
+	defer srv.Close()
+	s := New(srv.URL, decodeJson, WithCache(filepath.Join(t.TempDir(), "missing.json")))
+	if _, err := s.Load(context.Background()); err == nil || !strings.Contains(err.Error(), "404") {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
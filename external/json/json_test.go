@@ -1,6 +1,9 @@
 package json
 
 import (
+	"encoding/json"
+	"errors"
+	"fmt"
 	"reflect"
 	"testing"
 )
@@ -34,3 +37,284 @@ func TestJson_ParseSimple_Ok(t *testing.T) {
 		t.Fatalf("incorrect result: %#v", tc)
 	}
 }
+
+type textValue struct {
+	v string
+}
+
+func (t *textValue) UnmarshalText(b []byte) error {
+	t.v = "text:" + string(b)
+	return nil
+}
+
+type jsonValue struct {
+	raw string
+}
+
+func (j *jsonValue) UnmarshalJSON(b []byte) error {
+	j.raw = string(b)
+	return nil
+}
+
+type Embedded struct {
+	E     int
+	Inner int `json:"inner"`
+}
+
+type treeConfig struct {
+	*Embedded
+	Int      int8
+	Uint     uint16
+	Float    float32
+	Big      int64
+	Str      string `json:"str"`
+	Bool     bool
+	Bytes    []byte
+	Slice    []int
+	Array    [3]int
+	Map      map[string]int
+	IntKeys  map[int]string
+	TextKeys map[textValue]int
+	Text     textValue
+	TextPtr  *textValue
+	JSON     jsonValue
+	Ptr      **int
+	Quoted   int64       `json:"quoted,string"`
+	Number   json.Number `json:"number"`
+	Any      interface{}
+	Null     *int
+	Skipped  int `json:"-"`
+	Inner    int
+	hidden   int
+}
+
+func TestJson_DecodeTree_SameAsUnmarshal_Ok(t *testing.T) {
+	cases := []string{
+		`{"int": 1, "uint": 2, "float": 1.5, "big": 9007199254740993, "str": "s", "bool": true,
+		"bytes": "aGVsbG8=", "slice": [1, 2], "array": [1], "map": {"a": 1}, "intkeys": {"1": "a"},
+		"textkeys": {"k": 1}, "text": "t", "textptr": "p", "json": {"a": [1, null]}, "ptr": 5,
+		"any": {"a": [1, "b"]}, "null": null, "skipped": 1, "e": 3, "inner": 4, "Inner": 5, "hidden": 1}`,
+		`{"int": 300, "str": "after overflow"}`,
+		`{"str": 1, "bool": "true", "slice": {"a": 1}, "map": [1], "text": 1, "uint": -1, "int": "s"}`,
+		`{"intkeys": {"a": "b"}, "slice": [1, "a", 3]}`,
+		`{"STR": "fold", "Str": "fold2"}`,
+		`{"bytes": "invalid base64"}`,
+		`{"quoted": "42", "number": 12.5}`,
+		`{"quoted": 42}`,
+		`{}`,
+	}
+	for _, c := range cases {
+		expected := treeConfig{Slice: []int{9, 9, 9}, Array: [3]int{7, 7, 7}}
+		expectedErr := json.Unmarshal([]byte(c), &expected)
+		tree, assign, err := Json(c).DecodeTree()
+		if err != nil {
+			t.Fatal("Json.DecodeTree: ", err)
+		}
+		if _, ok := tree.(map[string]interface{}); !ok {
+			t.Fatalf("unexpected tree type: %T", tree)
+		}
+		actual := treeConfig{Slice: []int{9, 9, 9}, Array: [3]int{7, 7, 7}}
+		actualErr := assign(&actual)
+		// message of json.UnmarshalTypeError depends on go version, so only the mismatch is compared
+		var expectedType, actualType *json.UnmarshalTypeError
+		if (expectedErr == nil) != (actualErr == nil) ||
+			(errors.As(expectedErr, &expectedType) && errors.As(actualErr, &actualType) &&
+				(expectedType.Value != actualType.Value || expectedType.Type != actualType.Type)) {
+			t.Fatalf("%s: unexpected error. expected=%v actual=%v", c, expectedErr, actualErr)
+		}
+		if !reflect.DeepEqual(expected, actual) {
+			t.Fatalf("%s: unexpected result.\nexpected=%+v\nactual=%+v", c, expected, actual)
+		}
+	}
+}
+
+type plainItem struct {
+	Name string `json:"name,omitempty"`
+	Port *int
+}
+
+// plainConfig is assigned from the parsed tree, unlike treeConfig with embedded struct
+type plainConfig struct {
+	Str    string `json:"str"`
+	Int    int
+	Uint   uint8
+	Float  float64
+	Bool   bool
+	Items  []plainItem
+	Ptrs   []*plainItem
+	ByName map[string]plainItem
+	Named  map[textKey]string
+	Nested struct {
+		Text   textValue
+		Number json.Number
+		Any    interface{}
+	}
+	Bytes   []byte
+	Prefill []int
+	Array   [2]int
+	Any     interface{}
+	Skipped int `json:"-"`
+}
+
+type textKey string
+
+func TestJson_DecodeTree_PlainSameAsUnmarshal_Ok(t *testing.T) {
+	cases := []string{
+		`{"str": "a\"b\u00e9\ud83d\ude00", "int": -3, "uint": 255, "float": 1e3, "bool": false,
+		"items": [{"name": "a", "Port": 1}, {"name": "b", "port": null}], "ptrs": [{"name": "c"}, null],
+		"byName": {"x": {"name": "x"}, "y\n": {"Port": 2}}, "named": {"k": "v"},
+		"nested": {"text": "t", "number": 12.50, "any": [1, "a", {"b": null}]},
+		"bytes": "aGVsbG8=", "prefill": [1], "array": [1, 2, 3], "any": 1.5, "skipped": 1, "unknown": {"a": [1]}}`,
+		`{"str": "first", "str": "second", "items": [], "ptrs": null, "byName": {}}`,
+		`{"STR": "fold", "nested": {"TEXT": "fold"}}`,
+		`{"uint": 256, "str": "after overflow"}`,
+		`{"int": 1.5}`,
+		`{"items": [{"name": 1}], "float": 2}`,
+		`{"byName": {"a": []}, "bool": true}`,
+		`{"nested": []}`,
+		`[]`,
+		`null`,
+	}
+	for _, c := range cases {
+		expected := plainConfig{Prefill: []int{9, 9}, Any: map[string]interface{}{"old": 1}}
+		expectedErr := json.Unmarshal([]byte(c), &expected)
+		_, assign, err := Json(c).DecodeTree()
+		if err != nil {
+			t.Fatal("Json.DecodeTree: ", err)
+		}
+		actual := plainConfig{Prefill: []int{9, 9}, Any: map[string]interface{}{"old": 1}}
+		actualErr := assign(&actual)
+		if fmt.Sprint(expectedErr) != fmt.Sprint(actualErr) {
+			t.Fatalf("%s: unexpected error. expected=%v actual=%v", c, expectedErr, actualErr)
+		}
+		if !reflect.DeepEqual(expected, actual) {
+			t.Fatalf("%s: unexpected result.\nexpected=%+v\nactual=%+v", c, expected, actual)
+		}
+	}
+}
+
+func TestJson_DecodeTree_Tree_Ok(t *testing.T) {
+	tree, _, err := Json(`{"a": 1, "b": [2, {"c": "d"}], "e": null}`).DecodeTree()
+	if err != nil {
+		t.Fatal("Json.DecodeTree: ", err)
+	}
+	var expected interface{}
+	if err = json.Unmarshal([]byte(`{"a": 1, "b": [2, {"c": "d"}], "e": null}`), &expected); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(expected, tree) {
+		t.Fatalf("unexpected tree: %#v", tree)
+	}
+}
+
+func TestJson_DecodeTree_InvalidJson_Err(t *testing.T) {
+	for _, c := range []string{`{"a":`, `{} {}`, ``, `{"a": 01}`, `["a\x"]`} {
+		if _, _, err := Json(c).DecodeTree(); err == nil {
+			t.Fatalf("%q: expected error", c)
+		}
+	}
+}
+
+func TestJson_DecodePositions_Ok(t *testing.T) {
+	const data = `{
+	"db": {
+		"port": 5432,
+		"tags": ["a", "b"]
+	},
+	"name": "first", "name": "second"
+}`
+	_, position, _, err := Json(data).DecodePositions()
+	if err != nil {
+		t.Fatal("Json.DecodePositions: ", err)
+	}
+	cases := []struct {
+		path         []string
+		line, column int
+		ok           bool
+	}{
+		{nil, 1, 1, true},
+		{[]string{"db"}, 2, 8, true},
+		{[]string{"db", "port"}, 3, 11, true},
+		{[]string{"db", "tags", "1"}, 4, 17, true},
+		{[]string{"db", "tags", "2"}, 0, 0, false},
+		{[]string{"name"}, 6, 27, true},
+		{[]string{"db", "port", "x"}, 0, 0, false},
+	}
+	for _, c := range cases {
+		line, column, ok := position(c.path)
+		if line != c.line || column != c.column || ok != c.ok {
+			t.Fatalf("%v: unexpected position %d:%d %t", c.path, line, column, ok)
+		}
+	}
+}
+
+func TestJson_DecodeTree_StringOption_Ok(t *testing.T) {
+	var cfg struct {
+		A int64       `json:"a,string"`
+		N json.Number `json:"n"`
+	}
+	_, assign, err := Json(`{"a":"42","n":12.5}`).DecodeTree()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = assign(&cfg); err != nil {
+		t.Fatal(err)
+	}
+	if cfg.A != 42 || cfg.N != "12.5" {
+		t.Fatalf("unexpected result: %+v", cfg)
+	}
+}
+
+type benchConfig struct {
+	Name    string  `json:"name"`
+	Debug   bool    `json:"debug"`
+	Rate    float64 `json:"rate"`
+	Servers []struct {
+		Host    string   `json:"host"`
+		Port    int      `json:"port"`
+		Aliases []string `json:"aliases"`
+	} `json:"servers"`
+	Labels map[string]string `json:"labels"`
+	DB     struct {
+		Host     string `json:"host"`
+		Port     int    `json:"port"`
+		User     string `json:"user"`
+		Password string `json:"password"`
+	} `json:"db"`
+}
+
+const benchJSON = `{"name": "app", "debug": true, "rate": 1.5,
+	"servers": [{"host": "a", "port": 1, "aliases": ["a1", "a2"]}, {"host": "b", "port": 2}],
+	"labels": {"env": "prod", "team": "core"},
+	"db": {"host": "localhost", "port": 5432, "user": "admin", "password": "secret"}}`
+
+// BenchmarkJson_DecodeTree compares decoding of the tree with unmarshaling json twice:
+// into map[string]interface{} for the tree and into the struct
+func BenchmarkJson_DecodeTree(b *testing.B) {
+	b.Run("DecodeTree", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_, assign, err := Json(benchJSON).DecodeTree()
+			if err != nil {
+				b.Fatal(err)
+			}
+			var cfg benchConfig
+			if err = assign(&cfg); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("Unmarshal", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			mp := make(map[string]interface{})
+			if err := Json(benchJSON).Unmarshal(&mp); err != nil {
+				b.Fatal(err)
+			}
+			var cfg benchConfig
+			if err := Json(benchJSON).Unmarshal(&cfg); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
@@ -0,0 +1,465 @@
+package json
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+)
+
+// DecodeTree parses json once into the tree. Numbers of the tree are float64 as json.Unmarshal decodes them into interface{}.
+// Values are assigned from the parsed tree, see tree.assign
+func (j Json) DecodeTree() (interface{}, func(interface{}) error, error) {
+	t, err := j.decode()
+	if err != nil {
+		return nil, nil, err
+	}
+	return t.generic(0), t.assign, nil
+}
+
+// DecodePositions works as DecodeTree and additionally returns line and column of the value by path of keys
+func (j Json) DecodePositions() (interface{}, func([]string) (int, int, bool), func(interface{}) error, error) {
+	t, err := j.decode()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	l := newLines(j)
+	position := func(path []string) (int, int, bool) {
+		i := t.lookup(path)
+		if i < 0 {
+			return 0, 0, false
+		}
+		line, column := l.position(t.nodes[i].start)
+		return line, column, true
+	}
+	return t.generic(0), position, t.assign, nil
+}
+
+func (j Json) decode() (*tree, error) {
+	if !json.Valid(j) {
+		// error of the invalid json is reported as json.Unmarshal reports it
+		var v interface{}
+		return nil, json.Unmarshal(j, &v)
+	}
+	t := &tree{data: j, nodes: make([]node, 0, countValues(j))}
+	t.parse(0)
+	return t, nil
+}
+
+// tree is parsed json. Nodes are stored in the document order, the root is the first of them
+type tree struct {
+	data  []byte
+	nodes []node
+}
+
+// node is a parsed json value. kind is the first byte of the value: '{', '[', '"', 't', 'f', 'n' or '0' for numbers
+type node struct {
+	kind byte
+	// value is data[start:end]
+	start, end int
+	// first child and next sibling, -1 if there is none
+	child, next int
+	len         int
+	// key of the object member and value of the string.
+	// Strings are unquoted once and shared by the tree and assigned values
+	key, str string
+}
+
+// parse adds the value that starts at offset i and its children to the tree.
+// Data is validated before parsing. Returns index of the node and offset of the value end
+func (t *tree) parse(i int) (int, int) {
+	i = skipSpace(t.data, i)
+	idx := len(t.nodes)
+	kind := t.data[i]
+	if kind == '-' || (kind >= '0' && kind <= '9') {
+		kind = '0'
+	}
+	t.nodes = append(t.nodes, node{kind: kind, start: i, child: -1, next: -1})
+	switch kind {
+	case '{', '[':
+		closing := byte(']')
+		if kind == '{' {
+			closing = '}'
+		}
+		prev := -1
+		for i = skipSpace(t.data, i+1); t.data[i] != closing; {
+			var key string
+			if kind == '{' {
+				end := endOfString(t.data, i)
+				key = unquote(t.data[i:end])
+				// skipping ':'
+				i = skipSpace(t.data, end) + 1
+			}
+			var child int
+			child, i = t.parse(i)
+			t.nodes[child].key = key
+			if prev < 0 {
+				t.nodes[idx].child = child
+			} else {
+				t.nodes[prev].next = child
+			}
+			prev = child
+			t.nodes[idx].len++
+			if i = skipSpace(t.data, i); t.data[i] == ',' {
+				i = skipSpace(t.data, i+1)
+			}
+		}
+		i++
+	case '"':
+		i = endOfString(t.data, i)
+		t.nodes[idx].str = unquote(t.data[t.nodes[idx].start:i])
+	default:
+		for i < len(t.data) && strings.IndexByte(" \t\r\n,]}", t.data[i]) < 0 {
+			i++
+		}
+	}
+	t.nodes[idx].end = i
+	return idx, i
+}
+
+func skipSpace(data []byte, i int) int {
+	for i < len(data) && (data[i] == ' ' || data[i] == '\t' || data[i] == '\r' || data[i] == '\n') {
+		i++
+	}
+	return i
+}
+
+// countValues returns the upper bound of the number of values in valid json
+func countValues(data []byte) int {
+	result := 1
+	for i := 0; i < len(data); i++ {
+		switch data[i] {
+		case '"':
+			i = endOfString(data, i) - 1
+		case ',', '[', '{':
+			result++
+		}
+	}
+	return result
+}
+
+// endOfString returns offset after the closing quote of the string that starts at offset i
+func endOfString(data []byte, i int) int {
+	for i++; data[i] != '"'; i++ {
+		if data[i] == '\\' {
+			i++
+		}
+	}
+	return i + 1
+}
+
+func (t *tree) raw(i int) []byte {
+	return t.data[t.nodes[i].start:t.nodes[i].end]
+}
+
+// unquote returns value of the quoted json string
+func unquote(quoted []byte) string {
+	s := quoted[1 : len(quoted)-1]
+	if bytes.IndexByte(s, '\\') < 0 && utf8.Valid(s) {
+		return string(s)
+	}
+	// escaped sequences and invalid utf-8 are decoded by json.Unmarshal
+	var result string
+	json.Unmarshal(quoted, &result)
+	return result
+}
+
+// lines contains offsets of the line starts
+type lines []int
+
+func newLines(data []byte) lines {
+	result := lines{0}
+	for i, b := range data {
+		if b == '\n' {
+			result = append(result, i+1)
+		}
+	}
+	return result
+}
+
+// position returns line and column of the offset. Column is counted in bytes
+func (l lines) position(offset int) (int, int) {
+	i := sort.Search(len(l), func(i int) bool { return l[i] > offset }) - 1
+	return i + 1, offset - l[i] + 1
+}
+
+// lookup returns index of the nested node by path of keys or -1 if there is none.
+// The last of duplicated keys is used as json.Unmarshal does
+func (t *tree) lookup(path []string) int {
+	i := 0
+	for _, key := range path {
+		n := t.nodes[i]
+		next := -1
+		switch n.kind {
+		case '{':
+			for c := n.child; c >= 0; c = t.nodes[c].next {
+				if t.nodes[c].key == key {
+					next = c
+				}
+			}
+		case '[':
+			idx, err := strconv.Atoi(key)
+			if err != nil || idx < 0 {
+				return -1
+			}
+			for c := n.child; c >= 0 && next < 0; c, idx = t.nodes[c].next, idx-1 {
+				if idx == 0 {
+					next = c
+				}
+			}
+		}
+		if next < 0 {
+			return -1
+		}
+		i = next
+	}
+	return i
+}
+
+// generic returns value of the node in the same form as json.Unmarshal decodes it into interface{}
+func (t *tree) generic(i int) interface{} {
+	n := t.nodes[i]
+	switch n.kind {
+	case '{':
+		result := make(map[string]interface{}, n.len)
+		for c := n.child; c >= 0; c = t.nodes[c].next {
+			result[t.nodes[c].key] = t.generic(c)
+		}
+		return result
+	case '[':
+		result := make([]interface{}, 0, n.len)
+		for c := n.child; c >= 0; c = t.nodes[c].next {
+			result = append(result, t.generic(c))
+		}
+		return result
+	case '"':
+		return n.str
+	case 't', 'f':
+		return n.kind == 't'
+	case 'n':
+		return nil
+	default:
+		f, _ := strconv.ParseFloat(string(t.raw(i)), 64)
+		return f
+	}
+}
+
+// assign stores the parsed values into v with the rules of json.Unmarshal.
+// Values with custom decoding (json.Unmarshaler, encoding.TextUnmarshaler, `,string` option, json.Number, etc.)
+// are decoded from their part of data by json.Unmarshal. If a value can't be assigned,
+// json.Unmarshal decodes the whole data, so errors and partially assigned values are the same as its ones
+func (t *tree) assign(v interface{}) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || !t.set(0, rv.Elem()) {
+		return json.Unmarshal(t.data, v)
+	}
+	return nil
+}
+
+var (
+	unmarshalerType     = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+	numberType          = reflect.TypeOf(json.Number(""))
+)
+
+// custom reports whether values of the type aren't decoded by the default rules of json
+func custom(rt reflect.Type) bool {
+	pt := reflect.PointerTo(rt)
+	return rt == numberType || pt.Implements(unmarshalerType) || pt.Implements(textUnmarshalerType) ||
+		(rt.Kind() == reflect.Interface && rt.NumMethod() != 0)
+}
+
+// unmarshal decodes the node into addressable rv with json.Unmarshal
+func (t *tree) unmarshal(i int, rv reflect.Value) bool {
+	return json.Unmarshal(t.raw(i), rv.Addr().Interface()) == nil
+}
+
+// set assigns the node to addressable rv. Returns false if the value can't be assigned
+func (t *tree) set(i int, rv reflect.Value) bool {
+	n := t.nodes[i]
+	rt := rv.Type()
+	if n.kind == 'n' || custom(rt) {
+		return t.unmarshal(i, rv)
+	}
+	switch rv.Kind() {
+	case reflect.Interface:
+		if !rv.IsNil() {
+			return t.unmarshal(i, rv)
+		}
+		rv.Set(reflect.ValueOf(t.generic(i)))
+	case reflect.Pointer:
+		if rv.IsNil() {
+			rv.Set(reflect.New(rt.Elem()))
+		}
+		return t.set(i, rv.Elem())
+	case reflect.Struct:
+		return t.setStruct(i, rv)
+	case reflect.Map:
+		return t.setMap(i, rv)
+	case reflect.Slice:
+		if n.kind != '[' || rv.Cap() != 0 {
+			// []byte is decoded from base64 string, elements of the existing slice are reused
+			return t.unmarshal(i, rv)
+		}
+		s := reflect.MakeSlice(rt, n.len, n.len)
+		for c, idx := n.child, 0; c >= 0; c, idx = t.nodes[c].next, idx+1 {
+			if !t.set(c, s.Index(idx)) {
+				return false
+			}
+		}
+		rv.Set(s)
+	case reflect.Array:
+		return t.unmarshal(i, rv)
+	case reflect.String:
+		if n.kind != '"' {
+			return false
+		}
+		rv.SetString(n.str)
+	case reflect.Bool:
+		if n.kind != 't' && n.kind != 'f' {
+			return false
+		}
+		rv.SetBool(n.kind == 't')
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		v, err := strconv.ParseInt(string(t.raw(i)), 10, 64)
+		if n.kind != '0' || err != nil || rv.OverflowInt(v) {
+			return false
+		}
+		rv.SetInt(v)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		v, err := strconv.ParseUint(string(t.raw(i)), 10, 64)
+		if n.kind != '0' || err != nil || rv.OverflowUint(v) {
+			return false
+		}
+		rv.SetUint(v)
+	case reflect.Float32, reflect.Float64:
+		v, err := strconv.ParseFloat(string(t.raw(i)), rt.Bits())
+		if n.kind != '0' || err != nil || rv.OverflowFloat(v) {
+			return false
+		}
+		rv.SetFloat(v)
+	default:
+		return false
+	}
+	return true
+}
+
+func (t *tree) setStruct(i int, rv reflect.Value) bool {
+	n := t.nodes[i]
+	fields := cachedFields(rv.Type())
+	if fields == nil {
+		return t.unmarshal(i, rv)
+	}
+	if n.kind != '{' {
+		return false
+	}
+	for c := n.child; c >= 0; c = t.nodes[c].next {
+		idx, ok := fields.lookup(t.nodes[c].key)
+		if !ok {
+			// unknown keys are ignored
+			continue
+		}
+		if !t.set(c, rv.Field(idx)) {
+			return false
+		}
+	}
+	return true
+}
+
+func (t *tree) setMap(i int, rv reflect.Value) bool {
+	n := t.nodes[i]
+	rt := rv.Type()
+	if rt.Key().Kind() != reflect.String || custom(rt.Key()) {
+		return t.unmarshal(i, rv)
+	}
+	if n.kind != '{' {
+		return false
+	}
+	if rv.IsNil() {
+		rv.Set(reflect.MakeMapWithSize(rt, n.len))
+	}
+	key, elem := reflect.New(rt.Key()).Elem(), reflect.New(rt.Elem()).Elem()
+	for c := n.child; c >= 0; c = t.nodes[c].next {
+		elem.SetZero()
+		if !t.set(c, elem) {
+			return false
+		}
+		key.SetString(t.nodes[c].key)
+		rv.SetMapIndex(key, elem)
+	}
+	return true
+}
+
+// structFields contains json names of the struct fields and their indexes
+type structFields struct {
+	byName map[string]int
+	names  []string
+}
+
+// lookup returns index of the field with exact name, otherwise of the first field matching the key case-insensitively
+func (s *structFields) lookup(key string) (int, bool) {
+	if idx, ok := s.byName[key]; ok {
+		return idx, true
+	}
+	for _, name := range s.names {
+		if strings.EqualFold(name, key) {
+			return s.byName[name], true
+		}
+	}
+	return 0, false
+}
+
+var fieldCache sync.Map
+
+func cachedFields(rt reflect.Type) *structFields {
+	if f, ok := fieldCache.Load(rt); ok {
+		return f.(*structFields)
+	}
+	f, _ := fieldCache.LoadOrStore(rt, typeFields(rt))
+	return f.(*structFields)
+}
+
+// typeFields returns fields of the struct by json names.
+// Returns nil for the struct that is decoded by json.Unmarshal: with embedded fields, conflicting names or tag options
+func typeFields(rt reflect.Type) *structFields {
+	fields := &structFields{byName: make(map[string]int, rt.NumField())}
+	for i := 0; i < rt.NumField(); i++ {
+		sf := rt.Field(i)
+		if sf.Anonymous {
+			return nil
+		}
+		tag := sf.Tag.Get("json")
+		if !sf.IsExported() || tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+		if (opts != "" && opts != "omitempty") || !validName(name) {
+			return nil
+		}
+		if name == "" {
+			name = sf.Name
+		}
+		if _, ok := fields.byName[name]; ok {
+			return nil
+		}
+		fields.byName[name] = i
+		fields.names = append(fields.names, name)
+	}
+	return fields
+}
+
+// validName reports whether the name of json tag is used by json.Unmarshal
+func validName(name string) bool {
+	for _, c := range name {
+		if !strings.ContainsRune("!#$%&()*+-./:;<=>?@[]^_{|}~ ", c) && !unicode.IsLetter(c) && !unicode.IsDigit(c) {
+			return false
+		}
+	}
+	return true
+}
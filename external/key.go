@@ -0,0 +1,93 @@
+package external
+
+import (
+	"reflect"
+	"strings"
+	"unicode"
+)
+
+// FieldKey returns key of the struct field in the external source:
+// value of the first non-empty tag of tagNames or the field name
+func FieldKey(sf reflect.StructField, tagNames []string) string {
+	for _, tagName := range tagNames {
+		tag, ok := sf.Tag.Lookup(tagName)
+		if !ok {
+			continue
+		}
+		if idx := strings.IndexRune(tag, ','); idx != -1 {
+			tag = tag[:idx]
+		}
+		if tag != "" {
+			return tag
+		}
+	}
+	return sf.Name
+}
+
+// MatchKey reports whether key of the external source defines the struct field.
+// Key matches tag of the field or the field name. Keys starting with lower case letter
+// match the field name case-insensitively
+func MatchKey(key string, sf reflect.StructField, tagNames []string) bool {
+	for _, tagName := range tagNames {
+		tag, ok := sf.Tag.Lookup(tagName)
+		if ok {
+			idx := strings.IndexRune(tag, ',')
+			if idx != -1 {
+				tag = tag[:idx]
+			}
+			if key == tag {
+				return true
+			}
+		}
+	}
+	if key == sf.Name {
+		return true
+	}
+	// unexportable field. looking for any first match with EqualFold
+	return isLower(key) && strings.EqualFold(key, sf.Name)
+}
+
+// isLower reports whether the first letter of s is lower case
+func isLower(s string) bool {
+	for _, r := range s {
+		return unicode.IsLower(r)
+	}
+	return false
+}
+
+// Promoter is implemented by External that defines fields of exported embedded structs
+// by keys of the outer struct, e.g. `level` defines Common.Level of the embedded Common.
+// Keys of the embedded structs are still matched by their names.
+// Signature uses only builtin types, so implementations don't depend on this package
+type Promoter interface {
+	External
+	// PromotesEmbedded returns true if fields of embedded structs are promoted
+	PromotesEmbedded() bool
+}
+
+// promotes reports whether ext promotes fields of embedded structs
+func promotes(ext External) bool {
+	p, ok := ext.(Promoter)
+	return ok && p.PromotesEmbedded()
+}
+
+// PromotedIndex returns index of the field of exported embedded structs of rt that key defines,
+// in the form of reflect.Value.FieldByIndex. Fields of rt itself aren't matched.
+// Returns nil if key doesn't match any promoted field
+func PromotedIndex(rt reflect.Type, key string, tagNames []string) []int {
+	for i := 0; i < rt.NumField(); i++ {
+		sf := rt.Field(i)
+		if !sf.Anonymous || !sf.IsExported() || sf.Type.Kind() != reflect.Struct {
+			continue
+		}
+		for j := 0; j < sf.Type.NumField(); j++ {
+			if ef := sf.Type.Field(j); ef.IsExported() && MatchKey(key, ef, tagNames) {
+				return []int{i, j}
+			}
+		}
+		if index := PromotedIndex(sf.Type, key, tagNames); index != nil {
+			return append([]int{i}, index...)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,141 @@
+package external
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// Merge returns External that combines data of externals.
+// Objects are merged recursively, other values of later externals override values of earlier ones
+func Merge(exts ...External) External {
+	return mergedExternal(exts)
+}
+
+type mergedExternal []External
+
+func (m mergedExternal) TagName() []string {
+	var result []string
+	seen := make(map[string]bool)
+	for _, ext := range m {
+		for _, tag := range ext.TagName() {
+			if !seen[tag] {
+				seen[tag] = true
+				result = append(result, tag)
+			}
+		}
+	}
+	return result
+}
+
+// PromotesEmbedded returns true if every external promotes fields of embedded structs
+func (m mergedExternal) PromotesEmbedded() bool {
+	for _, ext := range m {
+		if !promotes(ext) {
+			return false
+		}
+	}
+	return len(m) != 0
+}
+
+func (m mergedExternal) Unmarshal(v interface{}) error {
+	_, assign, err := m.DecodeTree()
+	if err != nil {
+		return err
+	}
+	return assign(v)
+}
+
+func (m mergedExternal) DecodeTree() (interface{}, func(interface{}) error, error) {
+	tree, _, assign, err := m.DecodePositions()
+	return tree, assign, err
+}
+
+func (m mergedExternal) DecodePositions() (interface{}, func([]string) (int, int, bool), func(interface{}) error, error) {
+	tree, pos, assign, err := m.decode()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	position := func(path []string) (int, int, bool) {
+		p, ok := pos(path)
+		return p.Line, p.Column, ok
+	}
+	return tree, position, assign, nil
+}
+
+func (m mergedExternal) decode() (interface{}, func([]string) (Position, bool), func(interface{}) error, error) {
+	result := make(map[string]interface{})
+	trees := make([]interface{}, len(m))
+	positions := make([]func([]string) (Position, bool), len(m))
+	assigns := make([]func(interface{}) error, len(m))
+	for i, ext := range m {
+		tree, position, assign, err := decode(ext)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		mp, ok := tree.(map[string]interface{})
+		if !ok && tree != nil {
+			return nil, nil, nil, fmt.Errorf("unable to cast %T into map[string]interface{}", tree)
+		}
+		mergeMap(result, mp)
+		trees[i], positions[i], assigns[i] = tree, position, assign
+	}
+	// position is taken from the last external that defines the value
+	position := func(path []string) (Position, bool) {
+		for i := len(m) - 1; i >= 0; i-- {
+			if positions[i] == nil || !contains(trees[i], path) {
+				continue
+			}
+			return positions[i](path)
+		}
+		return Position{}, false
+	}
+	assign := func(v interface{}) error {
+		for _, assign := range assigns {
+			if err := assign(v); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return result, position, assign, nil
+}
+
+// mergeMap merges src into dst. Nested maps of src are copied, so trees of externals aren't changed
+func mergeMap(dst, src map[string]interface{}) {
+	for k, v := range src {
+		sm, ok := v.(map[string]interface{})
+		if !ok {
+			dst[k] = v
+			continue
+		}
+		dm, ok := dst[k].(map[string]interface{})
+		if !ok {
+			dm = make(map[string]interface{}, len(sm))
+			dst[k] = dm
+		}
+		mergeMap(dm, sm)
+	}
+}
+
+// contains reports whether tree has a value by path of keys
+func contains(tree interface{}, path []string) bool {
+	for _, key := range path {
+		switch vt := tree.(type) {
+		case map[string]interface{}:
+			v, ok := vt[key]
+			if !ok {
+				return false
+			}
+			tree = v
+		case []interface{}:
+			idx, err := strconv.Atoi(key)
+			if err != nil || idx < 0 || idx >= len(vt) {
+				return false
+			}
+			tree = vt[idx]
+		default:
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,183 @@
+package external
+
+import (
+	"strconv"
+)
+
+// Position of the value in the external source. Line and Column start at 1
+type Position struct {
+	// File is a path of the source file. Empty if source isn't read from file
+	File   string
+	Line   int
+	Column int
+}
+
+// IsValid reports whether position is known
+func (p Position) IsValid() bool {
+	return p.Line > 0
+}
+
+// String returns position in the file:line:column format
+func (p Position) String() string {
+	if !p.IsValid() {
+		return p.File
+	}
+	s := strconv.Itoa(p.Line) + ":" + strconv.Itoa(p.Column)
+	if p.File != "" {
+		s = p.File + ":" + s
+	}
+	return s
+}
+
+// PositionTree holds position of the value and positions of its nested values.
+// Children of arrays are keyed by index
+type PositionTree struct {
+	Position
+	Children map[string]*PositionTree
+}
+
+// Child returns positions of the nested value. Returns nil if key is unknown
+func (t *PositionTree) Child(key string) *PositionTree {
+	if t == nil {
+		return nil
+	}
+	return t.Children[key]
+}
+
+// Lookup returns position of the value by path of keys
+func (t *PositionTree) Lookup(path ...string) (Position, bool) {
+	for _, key := range path {
+		t = t.Child(key)
+	}
+	if t == nil || !t.IsValid() {
+		return Position{}, false
+	}
+	return t.Position, true
+}
+
+// add sets positions of the nested value. Does nothing if t or child is nil
+func (t *PositionTree) add(key string, child *PositionTree) {
+	if t == nil || child == nil {
+		return
+	}
+	if t.Children == nil {
+		t.Children = make(map[string]*PositionTree)
+	}
+	t.Children[key] = child
+}
+
+// with returns positions of the nested value, adding them if needed. Returns nil if t is nil
+func (t *PositionTree) with(key string) *PositionTree {
+	if t == nil {
+		return nil
+	}
+	child := t.Child(key)
+	if child == nil {
+		child = &PositionTree{}
+		t.add(key, child)
+	}
+	return child
+}
+
+// PositionDecoder is TreeDecoder that also reports positions of the decoded values.
+// Signature uses only builtin types, so implementations don't depend on this package
+type PositionDecoder interface {
+	TreeDecoder
+	// DecodePositions works as DecodeTree and additionally returns lookup of the value position
+	// by path of the tree keys. Elements of arrays are keyed by index. Line and column start at 1
+	DecodePositions() (tree interface{}, position func(path []string) (line, column int, ok bool), assign func(v interface{}) error, err error)
+}
+
+// AsPositionDecoder returns ext as PositionDecoder.
+// External that doesn't implement PositionDecoder is adapted with AsTreeDecoder and reports no positions
+func AsPositionDecoder(ext External) PositionDecoder {
+	if pd, ok := ext.(PositionDecoder); ok {
+		return pd
+	}
+	return noPositionDecoder{AsTreeDecoder(ext)}
+}
+
+type noPositionDecoder struct {
+	TreeDecoder
+}
+
+func (d noPositionDecoder) DecodePositions() (interface{}, func([]string) (int, int, bool), func(interface{}) error, error) {
+	tree, assign, err := d.DecodeTree()
+	return tree, nil, assign, err
+}
+
+// decode decodes tree of ext with positions of the values. File of the positions is a path of ext if it's known.
+// Externals combined by Merge keep files of their own positions
+func decode(ext External) (interface{}, func([]string) (Position, bool), func(interface{}) error, error) {
+	if m, ok := ext.(mergedExternal); ok {
+		return m.decode()
+	}
+	tree, position, assign, err := AsPositionDecoder(ext).DecodePositions()
+	if err != nil || position == nil {
+		return tree, nil, assign, err
+	}
+	var file string
+	if fp, ok := ext.(filePath); ok {
+		file = fp.Path()
+	}
+	pos := func(path []string) (Position, bool) {
+		line, column, ok := position(path)
+		return Position{File: file, Line: line, Column: column}, ok
+	}
+	return tree, pos, assign, nil
+}
+
+// PositionSource is ExternalSource that knows positions of its values
+type PositionSource interface {
+	ExternalSource
+	Position(key string) (Position, bool)
+}
+
+// PositionOf returns position of the value of es by key
+func PositionOf(es ExternalSource, key string) (Position, bool) {
+	if ps, ok := es.(PositionSource); ok {
+		return ps.Position(key)
+	}
+	return Position{}, false
+}
+
+type positionContainer struct {
+	ExternalSource
+	pos *PositionTree
+}
+
+func (c positionContainer) Position(key string) (Position, bool) {
+	return c.pos.Lookup(key)
+}
+
+// filePath is implemented by externals that are read from file.
+// Path of the file is set into positions of the values
+type filePath interface {
+	Path() string
+}
+
+// WithPath returns ext that reports path as the file of its values positions
+func WithPath(ext External, path string) External {
+	return &pathExternal{External: ext, path: path}
+}
+
+type pathExternal struct {
+	External
+	path string
+}
+
+func (e *pathExternal) Path() string {
+	return e.path
+}
+
+func (e *pathExternal) PromotesEmbedded() bool {
+	return promotes(e.External)
+}
+
+func (e *pathExternal) DecodeTree() (interface{}, func(interface{}) error, error) {
+	return AsTreeDecoder(e.External).DecodeTree()
+}
+
+func (e *pathExternal) DecodePositions() (interface{}, func([]string) (int, int, bool), func(interface{}) error, error) {
+	return AsPositionDecoder(e.External).DecodePositions()
+}
@@ -0,0 +1,22 @@
+package external
+
+import (
+	"context"
+)
+
+// Provider loads external data from slow or remote location, e.g. configuration service.
+// Providers are loaded with the context of EnvConf.ParseContext
+type Provider interface {
+	// Name identifies the provider in errors
+	Name() string
+	// Load fetches the data. Load should return when ctx is done
+	Load(ctx context.Context) (External, error)
+}
+
+// Watcher is a Provider that can detect changes of its data without loading it, e.g. by ETag of HTTP response.
+// EnvConf.Watch polls watchers and reloads configuration when data changed
+type Watcher interface {
+	Provider
+	// Changed reports whether data changed since the last Load
+	Changed(ctx context.Context) (bool, error)
+}
@@ -0,0 +1,34 @@
+package external
+
+// TreeDecoder is External that parses data once.
+// Parsed data is used both for assigning fields and for reading values of ExternalSource,
+// so implementations avoid decoding the same bytes twice
+type TreeDecoder interface {
+	External
+	// DecodeTree parses the external data and returns generic tree of it:
+	// map[string]interface{}, []interface{} and scalar values. assign stores the parsed data
+	// in the value pointed to by v without parsing it again
+	DecodeTree() (tree interface{}, assign func(v interface{}) error, err error)
+}
+
+// AsTreeDecoder returns ext as TreeDecoder.
+// External that doesn't implement TreeDecoder is adapted: data is unmarshaled into
+// map[string]interface{} for the tree and into the value on assign
+func AsTreeDecoder(ext External) TreeDecoder {
+	if td, ok := ext.(TreeDecoder); ok {
+		return td
+	}
+	return unmarshalTreeDecoder{ext}
+}
+
+type unmarshalTreeDecoder struct {
+	External
+}
+
+func (d unmarshalTreeDecoder) DecodeTree() (interface{}, func(interface{}) error, error) {
+	mp := make(map[string]interface{})
+	if err := d.Unmarshal(&mp); err != nil {
+		return nil, nil, err
+	}
+	return mp, d.Unmarshal, nil
+}
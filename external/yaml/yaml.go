@@ -1,6 +1,10 @@
 package yaml
 
-import "gopkg.in/yaml.v3"
+import (
+	"strconv"
+
+	"gopkg.in/yaml.v3"
+)
 
 type Yaml []byte
 
@@ -11,3 +15,92 @@ func (y Yaml) TagName() []string {
 func (y Yaml) Unmarshal(v interface{}) error {
 	return yaml.Unmarshal(y, v)
 }
+
+// DecodeTree parses yaml document once. Both the tree and assigned values are decoded from the parsed node
+func (y Yaml) DecodeTree() (interface{}, func(interface{}) error, error) {
+	tree, _, assign, err := y.DecodePositions()
+	return tree, assign, err
+}
+
+// DecodePositions works as DecodeTree and additionally returns line and column of the value by path of keys
+func (y Yaml) DecodePositions() (interface{}, func([]string) (int, int, bool), func(interface{}) error, error) {
+	var node yaml.Node
+	if err := yaml.Unmarshal(y, &node); err != nil {
+		return nil, nil, nil, err
+	}
+	if node.Kind == 0 {
+		// empty document
+		return map[string]interface{}{}, nil, func(interface{}) error { return nil }, nil
+	}
+	var tree map[string]interface{}
+	if err := node.Decode(&tree); err != nil {
+		return nil, nil, nil, err
+	}
+	position := func(path []string) (int, int, bool) {
+		n := lookup(&node, path)
+		if n == nil {
+			return 0, 0, false
+		}
+		return n.Line, n.Column, true
+	}
+	return tree, position, node.Decode, nil
+}
+
+// lookup returns node of the value by path of keys
+func lookup(n *yaml.Node, path []string) *yaml.Node {
+	for _, key := range path {
+		n = child(n, key)
+		if n == nil {
+			return nil
+		}
+	}
+	return n
+}
+
+func child(n *yaml.Node, key string) *yaml.Node {
+	n = resolve(n)
+	switch n.Kind {
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(n.Content); i += 2 {
+			if n.Content[i].Value == key && n.Content[i].Tag != "!!merge" {
+				return n.Content[i+1]
+			}
+		}
+		// keys of the mapping have priority over merged ones
+		for i := 0; i+1 < len(n.Content); i += 2 {
+			if n.Content[i].Tag != "!!merge" {
+				continue
+			}
+			merged := resolve(n.Content[i+1])
+			sources := []*yaml.Node{merged}
+			if merged.Kind == yaml.SequenceNode {
+				sources = merged.Content
+			}
+			for _, src := range sources {
+				if c := child(src, key); c != nil {
+					return c
+				}
+			}
+		}
+	case yaml.SequenceNode:
+		idx, err := strconv.Atoi(key)
+		if err == nil && idx >= 0 && idx < len(n.Content) {
+			return n.Content[idx]
+		}
+	}
+	return nil
+}
+
+// resolve returns content of the document and target of the alias
+func resolve(n *yaml.Node) *yaml.Node {
+	for {
+		switch {
+		case n.Kind == yaml.DocumentNode && len(n.Content) > 0:
+			n = n.Content[0]
+		case n.Kind == yaml.AliasNode && n.Alias != nil:
+			n = n.Alias
+		default:
+			return n
+		}
+	}
+}
@@ -29,3 +29,82 @@ b:
 		t.Errorf("incorrect values: %#v", tc)
 	}
 }
+
+func TestYamlConf_DecodeTree_Ok(t *testing.T) {
+	const data = `
+a: Easy!
+b:
+  c: 2
+  d: [3, 4]
+`
+	tree, assign, err := Yaml(data).DecodeTree()
+	if err != nil {
+		t.Fatal("Yaml.DecodeTree: ", err)
+	}
+	expected := map[string]interface{}{
+		"a": "Easy!",
+		"b": map[string]interface{}{"c": 2, "d": []interface{}{3, 4}},
+	}
+	if !reflect.DeepEqual(expected, tree) {
+		t.Fatalf("incorrect tree: %#v", tree)
+	}
+	tc := struct {
+		A string
+		B struct {
+			C int
+		}
+	}{}
+	if err = assign(&tc); err != nil {
+		t.Fatal("assign: ", err)
+	}
+	if tc.A != "Easy!" || tc.B.C != 2 {
+		t.Fatalf("incorrect values: %#v", tc)
+	}
+}
+
+func TestYamlConf_DecodeTree_Empty_Ok(t *testing.T) {
+	tree, assign, err := Yaml("").DecodeTree()
+	if err != nil {
+		t.Fatal("Yaml.DecodeTree: ", err)
+	}
+	if !reflect.DeepEqual(map[string]interface{}{}, tree) {
+		t.Fatalf("incorrect tree: %#v", tree)
+	}
+	if err = assign(&struct{ A int }{}); err != nil {
+		t.Fatal("assign: ", err)
+	}
+}
+
+func TestYamlConf_DecodePositions_Ok(t *testing.T) {
+	const data = `base: &base
+  host: localhost
+db:
+  <<: *base
+  port: 5432
+  tags:
+    - a
+    - b
+`
+	_, position, _, err := Yaml(data).DecodePositions()
+	if err != nil {
+		t.Fatal("Yaml.DecodePositions: ", err)
+	}
+	cases := []struct {
+		path         []string
+		line, column int
+		ok           bool
+	}{
+		{[]string{"db"}, 4, 3, true},
+		{[]string{"db", "port"}, 5, 9, true},
+		{[]string{"db", "host"}, 2, 9, true},
+		{[]string{"db", "tags", "1"}, 8, 7, true},
+		{[]string{"db", "tags", "2"}, 0, 0, false},
+		{[]string{"db", "user"}, 0, 0, false},
+	}
+	for _, c := range cases {
+		line, column, ok := position(c.path)
+		if line != c.line || column != c.column || ok != c.ok {
+			t.Fatalf("%v: unexpected position %d:%d %t", c.path, line, column, ok)
+		}
+	}
+}
@@ -0,0 +1,90 @@
+package envconf
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/antonmashko/envconf/external"
+)
+
+// checkExternalKeys verifies that fields of the structs reached by external data v
+// are mapped to different keys of the external source. Each struct type is checked once
+func checkExternalKeys(rt reflect.Type, v interface{}, tagNames []string, path string, visited map[reflect.Type]bool) error {
+	for rt.Kind() == reflect.Ptr {
+		rt = rt.Elem()
+	}
+	switch vt := v.(type) {
+	case []interface{}:
+		if rt.Kind() != reflect.Slice && rt.Kind() != reflect.Array {
+			return nil
+		}
+		for _, item := range vt {
+			if err := checkExternalKeys(rt.Elem(), item, tagNames, path, visited); err != nil {
+				return err
+			}
+		}
+	case map[string]interface{}:
+		switch rt.Kind() {
+		case reflect.Map:
+			for _, item := range vt {
+				if err := checkExternalKeys(rt.Elem(), item, tagNames, path, visited); err != nil {
+					return err
+				}
+			}
+		case reflect.Struct:
+			if isImpl(rt) {
+				return nil
+			}
+			if !visited[rt] {
+				visited[rt] = true
+				if err := checkStructKeys(rt, tagNames, path); err != nil {
+					return err
+				}
+			}
+			for k, item := range vt {
+				for i := 0; i < rt.NumField(); i++ {
+					sf := rt.Field(i)
+					if !sf.IsExported() || !external.MatchKey(k, sf, tagNames) {
+						continue
+					}
+					if err := checkExternalKeys(sf.Type, item, tagNames, fieldPath(path, sf), visited); err != nil {
+						return err
+					}
+					break
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// checkStructKeys verifies that fields of the struct are mapped to different keys
+func checkStructKeys(rt reflect.Type, tagNames []string, path string) error {
+	keys := make(map[string]string)
+	for i := 0; i < rt.NumField(); i++ {
+		sf := rt.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+		key := external.FieldKey(sf, tagNames)
+		if key == tagIgnored {
+			continue
+		}
+		fullName := fieldPath(path, sf)
+		if other, ok := keys[key]; ok {
+			return &Error{
+				FieldName: fullName,
+				Message:   fmt.Sprintf("external key %q is already used by %s", key, other),
+			}
+		}
+		keys[key] = fullName
+	}
+	return nil
+}
+
+func fieldPath(path string, sf reflect.StructField) string {
+	if path == "" {
+		return sf.Name
+	}
+	return path + fieldNameDelim + sf.Name
+}
@@ -135,6 +135,10 @@ func asConfigField(f field) *configField {
 		return ft.configField
 	case *collectionType:
 		return ft.configField
+	case *sliceType:
+		return ft.configField
+	case *mapType:
+		return ft.configField
 	default:
 		return nil
 	}
@@ -0,0 +1,469 @@
+package envconf
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"reflect"
+	"strconv"
+
+	"github.com/antonmashko/envconf/external"
+	"github.com/antonmashko/envconf/option"
+)
+
+// GenField describes configuration field for the parse functions generated by envconfgen.
+// Values are computed from the struct tags at generation time
+type GenField struct {
+	Name     string
+	FullName string
+	// Path contains names of the struct fields from the root struct to the field.
+	// It's used for reading values of external source
+	Path []string
+	// Parents contains names of the parent structs as used in FullName
+	Parents []string
+	Type    reflect.Type
+	// Flag and Env are names of flag and environment variable, "-" if not defined
+	Flag        string
+	Env         string
+	Default     string
+	HasDefault  bool
+	Required    bool
+	Secret      bool
+	Description string
+	// Sources are sources allowed by the sources tag. Nil if all sources are allowed
+	Sources []option.ConfigSource
+	// Priority is priority order of the priority tag of the field or its closest parent without duplicates.
+	// Nil if priority order of options is used
+	Priority []option.ConfigSource
+	// Slice is set for slices that are defined by elements. Elements are reported
+	// as fields named by their indexes, see Item
+	Slice bool
+	// CheckDefault verifies default value of the types with custom unmarshaling.
+	// Defaults of other types are verified at generation time
+	CheckDefault func(string) error
+}
+
+// Generated resolves configuration values for the parse functions generated by envconfgen.
+// It applies the same rules as Parse to the fields listed by the generated code,
+// so reflection over the configuration struct isn't required.
+// Generated isn't intended for direct use
+type Generated struct {
+	r      *resolver
+	fields map[*GenField]*genField
+	env    []string
+	ext    external.ExternalSource
+}
+
+type genField struct {
+	flag     *flagSource
+	sources  option.ConfigSource
+	priority []option.ConfigSource
+	secret   bool
+	// parent is the slice field of the element
+	parent *GenField
+}
+
+// ErrGeneratedSchema returns by generated parse functions if schema validation is enabled.
+// Schema is built with reflection, use EnvConf.Parse instead
+var ErrGeneratedSchema = errors.New("envconf: schema validation isn't supported by generated code")
+
+// Generated returns resolver for the generated parse function
+func (e *EnvConf) Generated(opts ...option.ClientOption) *Generated {
+	o := e.opts.Clone()
+	for i := range opts {
+		opts[i].Apply(o)
+	}
+	return &Generated{
+		r:      newResolver(e, o),
+		fields: make(map[*GenField]*genField),
+		ext:    external.NilContainer{},
+	}
+}
+
+// StrictPriority reports whether values of external source are decoded into the shadow copy of data.
+// See option.WithStrictPriority
+func (g *Generated) StrictPriority() bool {
+	return g.r.opts.StrictPriority()
+}
+
+// Init registers flag and environment variable of the field
+func (g *Generated) Init(f *GenField) error {
+	if err := g.r.registerName("env", f.Env, f.FullName); err != nil {
+		return err
+	}
+	if err := g.r.registerName("flag", f.Flag, f.FullName); err != nil {
+		return err
+	}
+	fs, err := g.r.registerFlag(&flagSource{name: f.Flag}, f.Description)
+	if err != nil {
+		return &Error{Inner: err, FieldName: f.FullName}
+	}
+	gf := &genField{flag: fs, sources: allSources, priority: f.Priority, secret: g.isSecret(f)}
+	if f.Sources != nil {
+		gf.sources = sourcesMask(f.Sources)
+	}
+	g.fields[f] = gf
+	g.env = append(g.env, f.Env)
+	if g.r.opts.HasSourcePolicy() {
+		if sources := g.r.opts.AllowedSources(g.initializedArg(f, gf)); sources != nil {
+			gf.sources &= sourcesMask(sources)
+		}
+	}
+	if f.HasDefault && f.CheckDefault != nil {
+		if err := f.CheckDefault(f.Default); err != nil {
+			// Parse reports default value with the type of the field, pointers aren't converted
+			ce := g.conversionError(f, f.Default, option.DefaultValue, err)
+			ce.Type = f.Type
+			return &Error{
+				Inner:     ce,
+				FieldName: f.FullName,
+				Message:   "invalid default value",
+			}
+		}
+	}
+	if g.r.opts.HasFieldInitialized() {
+		g.r.opts.OnFieldInitialized(g.initializedArg(f, gf))
+	}
+	return nil
+}
+
+// Begin parses command line and decodes external source into data
+func (g *Generated) Begin(data interface{}) error {
+	return g.BeginContext(context.Background(), data)
+}
+
+// BeginContext works as Begin, ctx is passed to providers of external data
+func (g *Generated) BeginContext(ctx context.Context, data interface{}) error {
+	if _, ok := g.r.opts.SchemaValidation(); ok && (g.r.opts.External() != nil || len(g.r.opts.Providers()) != 0) {
+		return ErrGeneratedSchema
+	}
+	flagMu.Lock()
+	if g.r.opts.Usage() != nil {
+		flag.Usage = g.r.opts.Usage()
+	}
+	flag.Parse()
+	var err error
+	if fp := g.r.opts.FlagParsed(); fp != nil {
+		err = fp()
+	}
+	flagMu.Unlock()
+	if err != nil {
+		return err
+	}
+	g.r.ctx = ctx
+	if g.r.ext, err = g.r.loadExternal(); err != nil {
+		return err
+	}
+	extMapper := external.NewExternalConfigMapper(g.r.ext)
+	if err = extMapper.Unmarshal(data); err != nil {
+		g.ext = extMapper.Data()
+		var secrets []interface{}
+		for f, gf := range g.fields {
+			if !gf.secret {
+				continue
+			}
+			if v, ok := g.externalSource(f).Read(f.Path[len(f.Path)-1]); ok {
+				secrets = append(secrets, v)
+			}
+		}
+		return redactMapping(err, secrets)
+	}
+	if err = g.r.checkUnknownKeys(extMapper.UnknownKeys()); err != nil {
+		return err
+	}
+	g.ext = extMapper.Data()
+	return nil
+}
+
+// Lookup returns value of the field from the source with the highest priority.
+// Value of external source is already decoded by Begin, in this case Lookup reports field as defined
+// and returns empty string. Generated code converts values of other sources and calls Defined.
+// Returns error if field is required but isn't defined.
+// As Parse does, slices are reported by generated code after their elements and aren't required
+func (g *Generated) Lookup(f *GenField) (string, option.ConfigSource, error) {
+	gf := g.fields[f]
+	if err := g.checkSources(f, gf); err != nil {
+		return "", option.NoConfigValue, g.fail(f, err)
+	}
+	for _, p := range g.order(gf) {
+		if gf.sources&p == 0 {
+			continue
+		}
+		v, cs := g.sourceValue(f, gf, p)
+		switch cs {
+		case option.NoConfigValue:
+			continue
+		case option.ExternalSource:
+			if v != nil && !f.Slice {
+				g.Defined(f, v, cs)
+			}
+			return "", cs, nil
+		}
+		str, ok := v.(string)
+		if !ok {
+			return "", cs, g.fail(f, &Error{
+				Inner:     g.conversionError(f, v, cs, ErrUnsupportedType),
+				FieldName: f.FullName,
+				Message:   "v is not string",
+			})
+		}
+		return str, cs, nil
+	}
+	if f.Slice {
+		return "", option.NoConfigValue, nil
+	}
+	g.fail(f, ErrConfigurationNotFound)
+	if f.Required {
+		return "", option.NoConfigValue, &Error{
+			Message:   "failed to define field",
+			Inner:     &RequiredError{FieldName: f.FullName},
+			FieldName: f.FullName,
+		}
+	}
+	return "", option.NoConfigValue, nil
+}
+
+// checkSources returns error if source that isn't allowed for the field provided a value
+func (g *Generated) checkSources(f *GenField, gf *genField) error {
+	if gf.sources == allSources {
+		return nil
+	}
+	for _, p := range g.order(gf) {
+		if gf.sources&p != 0 {
+			continue
+		}
+		if _, cs := g.sourceValue(f, gf, p); cs == option.NoConfigValue {
+			continue
+		}
+		return &Error{
+			Inner:     &SourceError{FieldName: f.FullName, Source: p, Allowed: g.priorityOrder(gf)},
+			FieldName: f.FullName,
+			Message:   "source is not allowed",
+		}
+	}
+	return nil
+}
+
+func (g *Generated) sourceValue(f *GenField, gf *genField, p option.ConfigSource) (interface{}, option.ConfigSource) {
+	switch p {
+	case option.FlagVariable:
+		return gf.flag.Value()
+	case option.EnvVariable:
+		return (&envSource{name: f.Env, parser: g.r}).Value()
+	case option.ExternalSource:
+		v, ok := g.externalSource(f).Read(f.Path[len(f.Path)-1])
+		if !ok {
+			return nil, option.NoConfigValue
+		}
+		return g.r.injectEnv(v)
+	case option.DefaultValue:
+		if f.HasDefault {
+			return f.Default, option.DefaultValue
+		}
+	}
+	return nil, option.NoConfigValue
+}
+
+// externalSource returns external source of the struct that contains the field
+func (g *Generated) externalSource(f *GenField) external.ExternalSource {
+	es := g.ext
+	for _, name := range f.Path[:len(f.Path)-1] {
+		es = external.AsExternalSource(name, es)
+	}
+	return es
+}
+
+// sourcePosition returns position of the field value in the source cs.
+// Only external source reports positions
+func (g *Generated) sourcePosition(f *GenField, cs option.ConfigSource) external.Position {
+	if cs != option.ExternalSource {
+		return external.Position{}
+	}
+	pos, _ := external.PositionOf(g.externalSource(f), f.Path[len(f.Path)-1])
+	return pos
+}
+
+// Defined reports that field is defined with value v from the source cs
+func (g *Generated) Defined(f *GenField, v interface{}, cs option.ConfigSource) {
+	secret := g.fields[f].secret
+	dv := g.defaultValue(f, secret)
+	if secret {
+		v = option.SecretMask
+	}
+	g.r.opts.OnFieldDefined(option.FieldDefinedArg{
+		Name:         f.Name,
+		FullName:     f.FullName,
+		Type:         f.Type,
+		Required:     f.Required,
+		Description:  f.Description,
+		FlagName:     f.Flag,
+		EnvName:      f.Env,
+		DefaultValue: dv,
+		Value:        v,
+		Source:       cs,
+		Secret:       secret,
+		Position:     g.sourcePosition(f, cs),
+	})
+}
+
+// Item returns element i of the slice field f and reports it as initialized.
+// Generated code defines elements in the same way as fields, errors of elements are reported for f too
+func (g *Generated) Item(f *GenField, i int) *GenField {
+	name := strconv.Itoa(i)
+	item := &GenField{
+		Name:     name,
+		FullName: f.FullName + fieldNameDelim + name,
+		Path:     append(f.Path[:len(f.Path):len(f.Path)], name),
+		Parents:  append(f.Parents[:len(f.Parents):len(f.Parents)], f.Name),
+		Type:     f.Type.Elem(),
+		Flag:     tagIgnored,
+		Env:      tagIgnored,
+		Secret:   f.Secret,
+		Priority: f.Priority,
+	}
+	gf := &genField{
+		flag:     &flagSource{name: tagIgnored},
+		sources:  allSources,
+		priority: f.Priority,
+		secret:   g.isSecret(item),
+		parent:   f,
+	}
+	g.fields[item] = gf
+	if g.r.opts.HasSourcePolicy() {
+		if sources := g.r.opts.AllowedSources(g.initializedArg(item, gf)); sources != nil {
+			gf.sources &= sourcesMask(sources)
+		}
+	}
+	if g.r.opts.HasFieldInitialized() {
+		g.r.opts.OnFieldInitialized(g.initializedArg(item, gf))
+	}
+	return item
+}
+
+// NotFound reports that value of the field isn't defined by any source
+func (g *Generated) NotFound(f *GenField) {
+	g.fail(f, ErrConfigurationNotFound)
+}
+
+// ConversionError returns error of converting value s from the source cs
+func (g *Generated) ConversionError(f *GenField, s string, cs option.ConfigSource, err error) error {
+	return g.fail(f, &Error{
+		Inner:     g.conversionError(f, s, cs, err),
+		FieldName: f.FullName,
+		Message:   "cannot set",
+	})
+}
+
+// UnmarshalError returns error of encoding.TextUnmarshaler or encoding.BinaryUnmarshaler of the field
+func (g *Generated) UnmarshalError(f *GenField, s string, cs option.ConfigSource, err error) error {
+	return g.fail(f, &Error{Inner: g.conversionError(f, s, cs, err), FieldName: f.FullName})
+}
+
+// End checks environment variables after all fields are defined
+func (g *Generated) End() error {
+	return g.r.checkUnknownEnv(g.env)
+}
+
+// fail follows resolver.fieldNotDefined: position of the value with the highest priority is set into error of the field.
+// Errors of elements are reported for the slice too
+func (g *Generated) fail(f *GenField, err error) error {
+	var pos external.Position
+	gf := g.fields[f]
+	for _, p := range g.priorityOrder(gf) {
+		if _, cs := g.sourceValue(f, gf, p); cs != option.NoConfigValue {
+			pos = g.sourcePosition(f, cs)
+			break
+		}
+	}
+	var e *Error
+	if errors.As(err, &e) && e.FieldName == f.FullName && !e.Position.IsValid() {
+		e.Position = pos
+	}
+	g.r.opts.OnFieldDefineErr(option.FieldDefineErrorArg{
+		Name:     f.Name,
+		FullName: f.FullName,
+		Err:      err,
+		Secret:   gf.secret,
+		Position: pos,
+	})
+	if gf.parent != nil {
+		g.fail(gf.parent, err)
+	}
+	return err
+}
+
+func (g *Generated) conversionError(f *GenField, v interface{}, cs option.ConfigSource, err error) *ConversionError {
+	// pointers are converted into their values as Parse does
+	t := f.Type
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	ce := &ConversionError{
+		FieldName: f.FullName,
+		Value:     v,
+		Type:      t,
+		Source:    cs,
+		Err:       err,
+	}
+	if g.fields[f].secret {
+		ce.Value = option.SecretMask
+		ce.Err = redact(err, v)
+	}
+	return ce
+}
+
+// isSecret follows configField.isSecret: names of the root struct and parents are checked by the name matcher
+func (g *Generated) isSecret(f *GenField) bool {
+	if f.Secret || isSecretType(f.Type) || g.r.opts.IsSecret(f.Name) {
+		return true
+	}
+	for i := len(f.Parents) - 1; i >= 0; i-- {
+		if g.r.opts.IsSecret(f.Parents[i]) {
+			return true
+		}
+	}
+	return g.r.opts.IsSecret("")
+}
+
+func (g *Generated) defaultValue(f *GenField, secret bool) interface{} {
+	if !f.HasDefault {
+		return nil
+	}
+	if secret {
+		return option.SecretMask
+	}
+	return f.Default
+}
+
+// order follows configField.priorityOrder: priority tag overrides priority order of options
+func (g *Generated) order(gf *genField) []option.ConfigSource {
+	if gf.priority != nil {
+		return gf.priority
+	}
+	return g.r.opts.PriorityOrder()
+}
+
+func (g *Generated) priorityOrder(gf *genField) []option.ConfigSource {
+	var result []option.ConfigSource
+	for _, p := range g.order(gf) {
+		if gf.sources&p != 0 {
+			result = append(result, p)
+		}
+	}
+	return result
+}
+
+func (g *Generated) initializedArg(f *GenField, gf *genField) option.FieldInitializedArg {
+	return option.FieldInitializedArg{
+		Name:          f.Name,
+		FullName:      f.FullName,
+		Type:          f.Type,
+		Required:      f.Required,
+		Description:   f.Description,
+		FlagName:      f.Flag,
+		EnvName:       f.Env,
+		DefaultValue:  g.defaultValue(f, gf.secret),
+		Secret:        gf.secret,
+		PriorityOrder: g.priorityOrder(gf),
+	}
+}
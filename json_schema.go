@@ -0,0 +1,289 @@
+package envconf
+
+import (
+	"encoding"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/antonmashko/envconf/external"
+	"github.com/antonmashko/envconf/jsonschema"
+)
+
+var (
+	textUnmarshalerType   = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+	binaryUnmarshalerType = reflect.TypeOf((*encoding.BinaryUnmarshaler)(nil)).Elem()
+	durationType          = reflect.TypeOf(time.Duration(0))
+)
+
+// JSONSchema generates JSON Schema (draft 2020-12) of the configuration file for data.
+// tagNames are struct tags used by external source for key names, e.g. json or yaml.
+// Fields without such tags are described with field name.
+func JSONSchema(data interface{}, tagNames ...string) (*jsonschema.Schema, error) {
+	if data == nil {
+		return nil, ErrNilData
+	}
+	rt := reflect.TypeOf(data)
+	for rt.Kind() == reflect.Ptr {
+		rt = rt.Elem()
+	}
+	if rt.Kind() != reflect.Struct {
+		return nil, errors.New("invalid type")
+	}
+	g := &schemaGenerator{
+		tagNames: tagNames,
+		required: true,
+		visited:  make(map[reflect.Type]bool),
+	}
+	return g.generate(rt)
+}
+
+func (g *schemaGenerator) generate(rt reflect.Type) (*jsonschema.Schema, error) {
+	s, err := g.typeSchema(rt)
+	if err != nil {
+		return nil, err
+	}
+	s.Schema = jsonschema.Draft
+	return s, nil
+}
+
+type schemaGenerator struct {
+	tagNames []string
+	required bool
+	visited  map[reflect.Type]bool
+}
+
+// isImpl checks types that are defined with encoding.TextUnmarshaler or encoding.BinaryUnmarshaler
+func isImpl(rt reflect.Type) bool {
+	pt := reflect.PointerTo(rt)
+	return rt.Implements(textUnmarshalerType) || pt.Implements(textUnmarshalerType) ||
+		rt.Implements(binaryUnmarshalerType) || pt.Implements(binaryUnmarshalerType)
+}
+
+// typeSchema follows the same type switch as createFieldFromValue.
+// Returns nil for unsupported types
+func (g *schemaGenerator) typeSchema(rt reflect.Type) (*jsonschema.Schema, error) {
+	if rt.Kind() == reflect.Ptr {
+		s, err := g.typeSchema(rt.Elem())
+		if err != nil || s == nil {
+			return s, err
+		}
+		if len(s.Type) != 0 && !s.Type.Has("null") {
+			s.Type = append(s.Type, "null")
+		}
+		return s, nil
+	}
+	if vt := revealedType(rt); vt != nil {
+		return g.typeSchema(vt)
+	}
+	if isImpl(rt) {
+		return &jsonschema.Schema{Type: jsonschema.Type{"string"}}, nil
+	}
+	if rt == durationType {
+		// json decodes integer of nanoseconds, yaml decodes duration strings
+		return &jsonschema.Schema{Type: jsonschema.Type{"integer", "string"}}, nil
+	}
+	switch rt.Kind() {
+	case reflect.Struct:
+		return g.structSchema(rt)
+	case reflect.Interface:
+		return &jsonschema.Schema{}, nil
+	case reflect.Array, reflect.Slice:
+		if rt.Elem().Kind() == reflect.Uint8 && rt.Kind() == reflect.Slice {
+			return &jsonschema.Schema{Type: jsonschema.Type{"string"}}, nil
+		}
+		items, err := g.typeSchema(rt.Elem())
+		if err != nil || items == nil {
+			return nil, err
+		}
+		s := &jsonschema.Schema{Type: jsonschema.Type{"array"}, Items: items}
+		if rt.Kind() == reflect.Array {
+			l := rt.Len()
+			s.MaxItems = &l
+		}
+		return s, nil
+	case reflect.Map:
+		elem, err := g.typeSchema(rt.Elem())
+		if err != nil || elem == nil {
+			return nil, err
+		}
+		return &jsonschema.Schema{Type: jsonschema.Type{"object"}, AdditionalProperties: elem}, nil
+	case reflect.Chan, reflect.Func, reflect.UnsafePointer, reflect.Uintptr:
+		// unsupported types
+		return nil, nil
+	case reflect.Bool:
+		return &jsonschema.Schema{Type: jsonschema.Type{"boolean"}}, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return &jsonschema.Schema{Type: jsonschema.Type{"integer"}}, nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		min := float64(0)
+		return &jsonschema.Schema{Type: jsonschema.Type{"integer"}, Minimum: &min}, nil
+	case reflect.Float32, reflect.Float64:
+		return &jsonschema.Schema{Type: jsonschema.Type{"number"}}, nil
+	case reflect.String, reflect.Complex64, reflect.Complex128:
+		return &jsonschema.Schema{Type: jsonschema.Type{"string"}}, nil
+	default:
+		return nil, nil
+	}
+}
+
+func (g *schemaGenerator) structSchema(rt reflect.Type) (*jsonschema.Schema, error) {
+	if g.visited[rt] {
+		// recursive type
+		return &jsonschema.Schema{}, nil
+	}
+	g.visited[rt] = true
+	defer delete(g.visited, rt)
+
+	s := &jsonschema.Schema{
+		Type:       jsonschema.Type{"object"},
+		Properties: make(map[string]*jsonschema.Schema),
+	}
+	for i := 0; i < rt.NumField(); i++ {
+		sf := rt.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+		key := g.key(sf)
+		if key == tagIgnored {
+			continue
+		}
+		fs, err := g.typeSchema(sf.Type)
+		if err != nil {
+			return nil, err
+		}
+		if fs == nil {
+			continue
+		}
+		if err = g.applyTags(fs, sf); err != nil {
+			return nil, &Error{
+				Inner:     err,
+				FieldName: rt.String() + fieldNameDelim + sf.Name,
+				Message:   "invalid tag",
+			}
+		}
+		if req, _ := strconv.ParseBool(sf.Tag.Get(tagRequired)); req && g.required {
+			s.Required = append(s.Required, key)
+		}
+		s.Properties[key] = fs
+	}
+	return s, nil
+}
+
+// key returns the same key of the field as external source mapping uses
+func (g *schemaGenerator) key(sf reflect.StructField) string {
+	return external.FieldKey(sf, g.tagNames)
+}
+
+func (g *schemaGenerator) applyTags(s *jsonschema.Schema, sf reflect.StructField) error {
+	s.Description = sf.Tag.Get(tagDescription)
+	secret, _ := strconv.ParseBool(sf.Tag.Get(tagSecret))
+	if dv, ok := sf.Tag.Lookup(tagDefault); ok && !secret && !isSecretType(sf.Type) {
+		v, err := schemaValue(sf.Type, dv)
+		if err != nil {
+			return fmt.Errorf("default: %w", err)
+		}
+		s.Default = v
+	}
+	if enum, ok := sf.Tag.Lookup(tagEnum); ok {
+		for _, item := range strings.Split(enum, ",") {
+			v, err := schemaValue(sf.Type, item)
+			if err != nil {
+				return fmt.Errorf("enum: %w", err)
+			}
+			s.Enum = append(s.Enum, v)
+		}
+	}
+	if pattern, ok := sf.Tag.Lookup(tagPattern); ok {
+		s.Pattern = pattern
+	}
+	for _, t := range []string{tagMin, tagMax} {
+		str, ok := sf.Tag.Lookup(t)
+		if !ok {
+			continue
+		}
+		v, err := strconv.ParseFloat(strings.TrimSpace(str), 64)
+		if err != nil {
+			return fmt.Errorf("%s: %w", t, err)
+		}
+		setLimit(s, t == tagMin, v)
+	}
+	return nil
+}
+
+// setLimit sets min or max keyword depending on schema type
+func setLimit(s *jsonschema.Schema, min bool, v float64) {
+	l := int(v)
+	switch {
+	case s.Type.Has("integer") || s.Type.Has("number"):
+		if min {
+			s.Minimum = &v
+		} else {
+			s.Maximum = &v
+		}
+	case s.Type.Has("string"):
+		if min {
+			s.MinLength = &l
+		} else {
+			s.MaxLength = &l
+		}
+	case s.Type.Has("array"):
+		if min {
+			s.MinItems = &l
+		} else {
+			s.MaxItems = &l
+		}
+	}
+}
+
+// schemaValue converts tag value into JSON value of the field type
+func schemaValue(rt reflect.Type, value string) (interface{}, error) {
+	for rt.Kind() == reflect.Ptr {
+		rt = rt.Elem()
+	}
+	if rt == durationType {
+		d, err := time.ParseDuration(value)
+		return int64(d), err
+	}
+	if isImpl(rt) {
+		return value, nil
+	}
+	switch rt.Kind() {
+	case reflect.Interface, reflect.String:
+		return value, nil
+	case reflect.Complex64, reflect.Complex128:
+		_, _, err := createFromString(rt, value)
+		return value, err
+	case reflect.Slice, reflect.Array:
+		if rt.Kind() == reflect.Slice && rt.Elem().Kind() == reflect.Uint8 {
+			return value, nil
+		}
+		sl := strings.Split(value, ",")
+		result := make([]interface{}, len(sl))
+		for i := range sl {
+			v, err := schemaValue(rt.Elem(), sl[i])
+			if err != nil {
+				return nil, err
+			}
+			result[i] = v
+		}
+		return result, nil
+	case reflect.Map:
+		result := make(map[string]interface{})
+		for _, item := range strings.Split(value, ",") {
+			key, val, _ := strings.Cut(item, ":")
+			v, err := schemaValue(rt.Elem(), val)
+			if err != nil {
+				return nil, err
+			}
+			result[key] = v
+		}
+		return result, nil
+	default:
+		_, v, err := createFromString(rt, value)
+		return v, err
+	}
+}
@@ -0,0 +1,118 @@
+package envconf_test
+
+import (
+	"encoding/json"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/antonmashko/envconf"
+	jsonconf "github.com/antonmashko/envconf/external/json"
+	"github.com/antonmashko/envconf/option"
+)
+
+func TestJSONSchema_Generate_Ok(t *testing.T) {
+	cfg := struct {
+		Name  string `json:"name" required:"true" description:"service name" pattern:"^[a-z]+$"`
+		Port  int    `yaml:"port" default:"8080" min:"1" max:"65535"`
+		Level string `json:"level,omitempty" enum:"debug,info"`
+		DB    *struct {
+			URL     *url.URL      `json:"url"`
+			Timeout time.Duration `json:"timeout" default:"5s"`
+		} `json:"db"`
+		Hosts   []string        `json:"hosts" default:"a,b"`
+		Labels  map[string]uint `json:"labels"`
+		Any     interface{}     `json:"any"`
+		Ignored string          `json:"-"`
+		Fn      func()          `json:"fn"`
+		private string
+	}{}
+	s, err := envconf.JSONSchema(&cfg, "json", "yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := json.Marshal(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	const expected = `{"$schema":"https://json-schema.org/draft/2020-12/schema","type":"object",` +
+		`"properties":{` +
+		`"any":{},` +
+		`"db":{"type":["object","null"],"properties":{"timeout":{"type":["integer","string"],"default":5000000000},"url":{"type":["string","null"]}}},` +
+		`"hosts":{"type":"array","default":["a","b"],"items":{"type":"string"}},` +
+		`"labels":{"type":"object","additionalProperties":{"type":"integer","minimum":0}},` +
+		`"level":{"type":"string","enum":["debug","info"]},` +
+		`"name":{"type":"string","description":"service name","pattern":"^[a-z]+$"},` +
+		`"port":{"type":"integer","default":8080,"minimum":1,"maximum":65535}},` +
+		`"required":["name"]}`
+	if string(b) != expected {
+		t.Fatalf("unexpected schema.\nexpected=%s\nactual=  %s", expected, string(b))
+	}
+}
+
+func TestJSONSchema_InvalidDefault_Err(t *testing.T) {
+	cfg := struct {
+		Port int `default:"abc"`
+	}{}
+	if _, err := envconf.JSONSchema(&cfg); err == nil {
+		t.Fatal("expected error but got nil")
+	}
+}
+
+func TestJSONSchema_UntaggedFieldName_Ok(t *testing.T) {
+	cfg := struct {
+		HTTPAddr string `pattern:"^:[0-9]+$"`
+	}{}
+	s, err := envconf.JSONSchema(&cfg, "json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := s.Properties["HTTPAddr"]; !ok {
+		t.Fatalf("unexpected properties: %v", s.Properties)
+	}
+	// keys are matched in the same way as external source is mapped into the struct
+	for doc, valid := range map[string]bool{
+		`{"HTTPAddr": "localhost"}`: false,
+		`{"httpAddr": "localhost"}`: false,
+		`{"HTTPADDR": "localhost"}`: true,
+	} {
+		var v interface{}
+		if err := json.Unmarshal([]byte(doc), &v); err != nil {
+			t.Fatal(err)
+		}
+		if err := s.Validate(v); (err == nil) != valid {
+			t.Fatalf("unexpected result of %s: %v", doc, err)
+		}
+	}
+}
+
+func TestJSONSchema_Duration_Ok(t *testing.T) {
+	cfg := struct {
+		Timeout time.Duration `json:"timeout"`
+	}{}
+	s, err := envconf.JSONSchema(&cfg, "json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// integer of nanoseconds is decoded by json, duration string by yaml
+	for doc, valid := range map[string]bool{
+		`{"timeout": 5000000000}`: true,
+		`{"timeout": "5s"}`:       true,
+		`{"timeout": true}`:       false,
+	} {
+		var v interface{}
+		if err := json.Unmarshal([]byte(doc), &v); err != nil {
+			t.Fatal(err)
+		}
+		if err := s.Validate(v); (err == nil) != valid {
+			t.Fatalf("unexpected result of %s: %v", doc, err)
+		}
+	}
+	err = envconf.Parse(&cfg,
+		option.WithExternal(jsonconf.Json(`{"timeout": 5000000000}`)),
+		option.WithSchemaValidation(nil),
+	)
+	if err != nil || cfg.Timeout != 5*time.Second {
+		t.Fatalf("unexpected result: %v %v", err, cfg.Timeout)
+	}
+}
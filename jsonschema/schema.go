@@ -0,0 +1,75 @@
+// Package jsonschema contains subset of JSON Schema (draft 2020-12)
+// that used for describing configuration files.
+package jsonschema
+
+import (
+	"encoding/json"
+)
+
+// Draft is a JSON Schema dialect of generated schemas
+const Draft = "https://json-schema.org/draft/2020-12/schema"
+
+// Type is a JSON Schema "type" keyword. Single type is encoded as a string
+type Type []string
+
+func (t Type) MarshalJSON() ([]byte, error) {
+	if len(t) == 1 {
+		return json.Marshal(t[0])
+	}
+	return json.Marshal([]string(t))
+}
+
+func (t *Type) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err == nil {
+		*t = Type{s}
+		return nil
+	}
+	var sl []string
+	if err := json.Unmarshal(b, &sl); err != nil {
+		return err
+	}
+	*t = Type(sl)
+	return nil
+}
+
+// Has reports whether type list contains specified type
+func (t Type) Has(s string) bool {
+	for _, tt := range t {
+		if tt == s {
+			return true
+		}
+	}
+	return false
+}
+
+// Schema is a JSON Schema document
+type Schema struct {
+	Schema      string        `json:"$schema,omitempty"`
+	Type        Type          `json:"type,omitempty"`
+	Description string        `json:"description,omitempty"`
+	Default     interface{}   `json:"default,omitempty"`
+	Format      string        `json:"format,omitempty"`
+	Enum        []interface{} `json:"enum,omitempty"`
+	Pattern     string        `json:"pattern,omitempty"`
+	Minimum     *float64      `json:"minimum,omitempty"`
+	Maximum     *float64      `json:"maximum,omitempty"`
+	MinLength   *int          `json:"minLength,omitempty"`
+	MaxLength   *int          `json:"maxLength,omitempty"`
+	MinItems    *int          `json:"minItems,omitempty"`
+	MaxItems    *int          `json:"maxItems,omitempty"`
+
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+}
+
+// Parse decodes JSON Schema document
+func Parse(b []byte) (*Schema, error) {
+	var s Schema
+	if err := json.Unmarshal(b, &s); err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
@@ -0,0 +1,249 @@
+package jsonschema
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Violation is a single schema violation of the document value addressed by JSON Pointer
+type Violation struct {
+	Pointer string
+	Message string
+}
+
+func (v Violation) String() string {
+	ptr := v.Pointer
+	if ptr == "" {
+		ptr = "/"
+	}
+	return ptr + ": " + v.Message
+}
+
+// Violations is an error with all violations found in the document
+type Violations []Violation
+
+func (v Violations) Error() string {
+	msgs := make([]string, len(v))
+	for i := range v {
+		msgs[i] = v[i].String()
+	}
+	return "schema validation failed: " + strings.Join(msgs, "; ")
+}
+
+// Validate checks decoded document (maps, slices and scalar values) against schema.
+// Returns Violations with every problem found. Messages don't contain values of the document,
+// so values of secret fields aren't leaked.
+// Object keys starting with lower case letter that don't match property exactly
+// are matched case-insensitively, the same way envconf maps external keys to struct fields.
+func (s *Schema) Validate(doc interface{}) error {
+	v := &validator{patterns: make(map[string]*regexp.Regexp)}
+	v.validate(s, "", doc)
+	if len(v.violations) == 0 {
+		return nil
+	}
+	return v.violations
+}
+
+type validator struct {
+	violations Violations
+	patterns   map[string]*regexp.Regexp
+}
+
+func (v *validator) addf(ptr string, format string, args ...interface{}) {
+	v.violations = append(v.violations, Violation{Pointer: ptr, Message: fmt.Sprintf(format, args...)})
+}
+
+func (v *validator) validate(s *Schema, ptr string, doc interface{}) {
+	if s == nil {
+		return
+	}
+	if len(s.Type) != 0 {
+		t := typeOf(doc)
+		if !s.Type.Has(t) && !(t == "integer" && s.Type.Has("number")) {
+			v.addf(ptr, "expected %s but got %s", strings.Join(s.Type, " or "), t)
+			return
+		}
+	}
+	if len(s.Enum) != 0 && !v.inEnum(s.Enum, doc) {
+		v.addf(ptr, "value is not one of %v", s.Enum)
+	}
+	switch dt := doc.(type) {
+	case map[string]interface{}:
+		v.validateObject(s, ptr, dt)
+	case []interface{}:
+		if s.MinItems != nil && len(dt) < *s.MinItems {
+			v.addf(ptr, "expected at least %d items but got %d", *s.MinItems, len(dt))
+		}
+		if s.MaxItems != nil && len(dt) > *s.MaxItems {
+			v.addf(ptr, "expected at most %d items but got %d", *s.MaxItems, len(dt))
+		}
+		for i := range dt {
+			v.validate(s.Items, ptr+"/"+strconv.Itoa(i), dt[i])
+		}
+	case string:
+		l := utf8.RuneCountInString(dt)
+		if s.MinLength != nil && l < *s.MinLength {
+			v.addf(ptr, "expected length at least %d but got %d", *s.MinLength, l)
+		}
+		if s.MaxLength != nil && l > *s.MaxLength {
+			v.addf(ptr, "expected length at most %d but got %d", *s.MaxLength, l)
+		}
+		if s.Pattern != "" {
+			re, err := v.pattern(s.Pattern)
+			if err != nil {
+				v.addf(ptr, "invalid pattern %q: %s", s.Pattern, err)
+			} else if !re.MatchString(dt) {
+				v.addf(ptr, "value doesn't match pattern %q", s.Pattern)
+			}
+		}
+	default:
+		n, ok := number(doc)
+		if !ok {
+			return
+		}
+		if s.Minimum != nil && n < *s.Minimum {
+			v.addf(ptr, "value is less than minimum %v", *s.Minimum)
+		}
+		if s.Maximum != nil && n > *s.Maximum {
+			v.addf(ptr, "value is greater than maximum %v", *s.Maximum)
+		}
+	}
+}
+
+func (v *validator) validateObject(s *Schema, ptr string, mp map[string]interface{}) {
+	matched := make(map[string]bool, len(mp))
+	for _, name := range s.Required {
+		if _, ok := lookup(mp, name); !ok {
+			v.addf(ptr, "missing required property %q", name)
+		}
+	}
+	for _, name := range sortedKeys(s.Properties) {
+		key, ok := lookup(mp, name)
+		if !ok {
+			continue
+		}
+		matched[key] = true
+		v.validate(s.Properties[name], ptr+"/"+escape(key), mp[key])
+	}
+	if s.AdditionalProperties == nil {
+		return
+	}
+	for _, key := range sortedKeys(mp) {
+		if matched[key] {
+			continue
+		}
+		v.validate(s.AdditionalProperties, ptr+"/"+escape(key), mp[key])
+	}
+}
+
+func (v *validator) pattern(p string) (*regexp.Regexp, error) {
+	if re, ok := v.patterns[p]; ok {
+		return re, nil
+	}
+	re, err := regexp.Compile(p)
+	if err != nil {
+		return nil, err
+	}
+	v.patterns[p] = re
+	return re, nil
+}
+
+func (v *validator) inEnum(enum []interface{}, doc interface{}) bool {
+	dn, isNum := number(doc)
+	for _, e := range enum {
+		if en, ok := number(e); ok && isNum {
+			if en == dn {
+				return true
+			}
+			continue
+		}
+		if reflect.DeepEqual(e, doc) {
+			return true
+		}
+	}
+	return false
+}
+
+func sortedKeys[T any](mp map[string]T) []string {
+	keys := make([]string, 0, len(mp))
+	for k := range mp {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// lookup finds key in the map, exact match has priority over case-insensitive.
+// Only keys starting with lower case letter are matched case-insensitively
+func lookup(mp map[string]interface{}, name string) (string, bool) {
+	if _, ok := mp[name]; ok {
+		return name, true
+	}
+	for _, k := range sortedKeys(mp) {
+		if isLower(k) && strings.EqualFold(k, name) {
+			return k, true
+		}
+	}
+	return "", false
+}
+
+// isLower reports whether the first letter of s is lower case
+func isLower(s string) bool {
+	for _, r := range s {
+		return unicode.IsLower(r)
+	}
+	return false
+}
+
+// escape escapes JSON Pointer reference token (RFC 6901)
+func escape(s string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(s, "~", "~0"), "/", "~1")
+}
+
+func typeOf(v interface{}) string {
+	switch vt := v.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case string, time.Time:
+		return "string"
+	case map[string]interface{}:
+		return "object"
+	case []interface{}:
+		return "array"
+	case float32, float64:
+		n, _ := number(vt)
+		if n == math.Trunc(n) && !math.IsInf(n, 0) {
+			return "integer"
+		}
+		return "number"
+	default:
+		if _, ok := number(v); ok {
+			return "integer"
+		}
+		return reflect.TypeOf(v).String()
+	}
+}
+
+func number(v interface{}) (float64, bool) {
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(rv.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(rv.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return rv.Float(), true
+	default:
+		return 0, false
+	}
+}
@@ -0,0 +1,103 @@
+package jsonschema
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestValidate_AllViolations_Err(t *testing.T) {
+	const schema = `{
+		"type": "object",
+		"required": ["name"],
+		"properties": {
+			"port": {"type": "integer", "minimum": 1, "maximum": 65535},
+			"level": {"type": "string", "enum": ["debug", "info"]},
+			"a/b": {"type": "array", "maxItems": 1, "items": {"type": "string", "pattern": "^[a-z]+$"}},
+			"db": {"type": "object", "properties": {"Timeout": {"type": ["integer", "string"]}}},
+			"labels": {"type": "object", "additionalProperties": {"type": "boolean"}}
+		}
+	}`
+	s, err := Parse([]byte(schema))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var doc interface{}
+	err = json.Unmarshal([]byte(`{
+		"port": 70000,
+		"level": "trace",
+		"a/b": ["ok", "NOT"],
+		"db": {"timeout": true},
+		"labels": {"x": true, "y": 1}
+	}`), &doc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = s.Validate(doc)
+	vs, ok := err.(Violations)
+	if !ok {
+		t.Fatalf("unexpected error: %#v", err)
+	}
+	expected := map[string]bool{
+		"/":           true,
+		"/port":       true,
+		"/level":      true,
+		"/a~1b":       true,
+		"/a~1b/1":     true,
+		"/db/timeout": true,
+		"/labels/y":   true,
+	}
+	if len(vs) != len(expected) {
+		t.Fatalf("unexpected violations: %s", vs)
+	}
+	for _, v := range vs {
+		ptr := v.Pointer
+		if ptr == "" {
+			ptr = "/"
+		}
+		if !expected[ptr] {
+			t.Fatalf("unexpected violation: %s", v)
+		}
+	}
+}
+
+func TestValidate_NoValuesInMessages_Ok(t *testing.T) {
+	min, max := float64(10), float64(20)
+	s := &Schema{
+		Type: Type{"object"},
+		Properties: map[string]*Schema{
+			"password": {Type: Type{"string"}, Pattern: "^[a-z]+$", Enum: []interface{}{"a", "b"}},
+			"pin":      {Type: Type{"integer"}, Minimum: &min},
+			"code":     {Type: Type{"integer"}, Maximum: &max},
+		},
+	}
+	err := s.Validate(map[string]interface{}{"password": "Hunter2!", "pin": 4242.0 - 4241, "code": 4242.0})
+	if vs, ok := err.(Violations); !ok || len(vs) != 4 {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, value := range []string{"Hunter2!", "4242"} {
+		if strings.Contains(err.Error(), value) {
+			t.Fatalf("value %q is leaked: %s", value, err)
+		}
+	}
+}
+
+func TestValidate_Valid_Ok(t *testing.T) {
+	min := float64(0)
+	s := &Schema{
+		Type: Type{"object"},
+		Properties: map[string]*Schema{
+			"count": {Type: Type{"integer", "null"}, Minimum: &min},
+			"ratio": {Type: Type{"number"}},
+			"any":   {},
+		},
+	}
+	doc := map[string]interface{}{"count": 1, "ratio": 1.5, "any": []interface{}{1, "a"}}
+	if err := s.Validate(doc); err != nil {
+		t.Fatal(err)
+	}
+	doc["count"] = nil
+	if err := s.Validate(doc); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,90 @@
+package envconf
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/antonmashko/envconf/external"
+)
+
+// loadExternal returns external source of the options merged with data of the providers
+func (r *resolver) loadExternal() (external.External, error) {
+	ext := r.opts.External()
+	providers := r.opts.Providers()
+	if len(providers) == 0 {
+		return ext, nil
+	}
+	loaded, err := loadProviders(r.ctx, providers, r.opts.LoadConcurrency())
+	if err != nil {
+		return nil, err
+	}
+	if ext != nil {
+		loaded = append([]external.External{ext}, loaded...)
+	}
+	return external.Merge(loaded...), nil
+}
+
+// loadProviders loads providers concurrently, at most limit of them at once.
+// Returns externals in the order of providers. Loading stops on the first error.
+// If ctx is done before all providers are loaded, error names every provider that didn't finish
+func loadProviders(ctx context.Context, providers []external.Provider, limit int) ([]external.External, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	type result struct {
+		idx int
+		ext external.External
+		err error
+	}
+	// results are buffered, so providers that return after ctx is done don't block
+	results := make(chan result, len(providers))
+	sem := make(chan struct{}, limit)
+	// started and returned report providers that are stalled in Load
+	var mu sync.Mutex
+	started := make([]bool, len(providers))
+	returned := make([]bool, len(providers))
+	for i, p := range providers {
+		go func(i int, p external.Provider) {
+			select {
+			case sem <- struct{}{}:
+			case <-ctx.Done():
+				results <- result{idx: i, err: ctx.Err()}
+				return
+			}
+			defer func() { <-sem }()
+			mu.Lock()
+			started[i] = true
+			mu.Unlock()
+			ext, err := p.Load(ctx)
+			mu.Lock()
+			returned[i] = true
+			mu.Unlock()
+			results <- result{idx: i, ext: ext, err: err}
+		}(i, p)
+	}
+	exts := make([]external.External, len(providers))
+	for range providers {
+		select {
+		case res := <-results:
+			if res.err != nil {
+				return nil, &LoadError{Source: providers[res.idx].Name(), Err: res.err}
+			}
+			exts[res.idx] = res.ext
+		case <-ctx.Done():
+			// providers that ignore ctx can't block parsing
+			mu.Lock()
+			defer mu.Unlock()
+			var errs []error
+			for i, p := range providers {
+				if started[i] && !returned[i] {
+					errs = append(errs, &LoadError{Source: p.Name(), Err: ctx.Err()})
+				}
+			}
+			if len(errs) == 0 {
+				return nil, ctx.Err()
+			}
+			return nil, errors.Join(errs...)
+		}
+	}
+	return exts, nil
+}
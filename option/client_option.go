@@ -1,9 +1,11 @@
 package option
 
 import (
+	"os"
 	"reflect"
 
 	"github.com/antonmashko/envconf/external"
+	"github.com/antonmashko/envconf/jsonschema"
 )
 
 type FieldInitializedArg struct {
@@ -16,6 +18,10 @@ type FieldInitializedArg struct {
 	FlagName     string
 	EnvName      string
 	DefaultValue interface{}
+	// Secret is true if field holds sensitive value. DefaultValue is redacted for such fields
+	Secret bool
+	// PriorityOrder is an effective priority order of the field sources
+	PriorityOrder []ConfigSource
 }
 
 type FieldDefinedArg struct {
@@ -31,6 +37,10 @@ type FieldDefinedArg struct {
 
 	Source ConfigSource
 	Value  interface{}
+	// Secret is true if field holds sensitive value. Value and DefaultValue are redacted for such fields
+	Secret bool
+	// Position of the value in the external source. Invalid for other sources
+	Position external.Position
 }
 
 type FieldDefineErrorArg struct {
@@ -38,8 +48,14 @@ type FieldDefineErrorArg struct {
 	FullName string
 	Type     reflect.Type
 	Err      error
+	Secret   bool
+	// Position of the external source value that failed. Invalid for other sources
+	Position external.Position
 }
 
+// SecretMask replaces values of secret fields
+const SecretMask = "******"
+
 type ClientOption interface {
 	Apply(*Options)
 }
@@ -53,6 +69,29 @@ type Options struct {
 	onFieldDefined     func(FieldDefinedArg)
 	onFieldDefineErr   func(FieldDefineErrorArg)
 	externalInjection  func(string) (string, ConfigSource)
+	schemaValidation   bool
+	schema             *jsonschema.Schema
+	strictExternal     bool
+	onUnknownKey       func(UnknownKeyArg)
+	envCheck           *envCheck
+	secretName         func(string) bool
+	sourcePolicy       SourcePolicy
+	strictPriority     bool
+	sharedEnv          bool
+	reloadSignals      []os.Signal
+	reloadSubscribers  []reloadSubscriber
+	providers          []external.Provider
+	loadConcurrency    int
+}
+
+// Clone returns copy of the options. Applying options to the copy doesn't change o
+func (o *Options) Clone() *Options {
+	c := *o
+	c.priorityOrder = append([]ConfigSource(nil), o.priorityOrder...)
+	c.reloadSignals = append([]os.Signal(nil), o.reloadSignals...)
+	c.reloadSubscribers = append([]reloadSubscriber(nil), o.reloadSubscribers...)
+	c.providers = append([]external.Provider(nil), o.providers...)
+	return &c
 }
 
 func (o *Options) External() external.External {
@@ -79,12 +118,24 @@ func (o *Options) FlagParsed() func() error {
 	return o.flagParsed
 }
 
+// HasFieldInitialized returns true if OnFieldInitialized calls a hook.
+// Used to skip building FieldInitializedArg
+func (o *Options) HasFieldInitialized() bool {
+	return o.onFieldInitialized != nil
+}
+
 func (o *Options) OnFieldInitialized(arg FieldInitializedArg) {
 	if o.onFieldInitialized != nil {
 		o.onFieldInitialized(arg)
 	}
 }
 
+// HasFieldDefined returns true if OnFieldDefined calls a hook.
+// Used to skip building FieldDefinedArg
+func (o *Options) HasFieldDefined() bool {
+	return o.onFieldDefined != nil
+}
+
 func (o *Options) OnFieldDefined(arg FieldDefinedArg) {
 	if o.onFieldDefined != nil {
 		o.onFieldDefined(arg)
@@ -100,3 +151,87 @@ func (o *Options) OnFieldDefineErr(arg FieldDefineErrorArg) {
 func (o *Options) ExternalInjection() func(string) (string, ConfigSource) {
 	return o.externalInjection
 }
+
+// SchemaValidation returns JSON Schema for external source validation.
+// Returns false if validation is disabled
+func (o *Options) SchemaValidation() (*jsonschema.Schema, bool) {
+	return o.schema, o.schemaValidation
+}
+
+// StrictExternal returns true if unknown keys in external source should fail parsing
+func (o *Options) StrictExternal() bool {
+	return o.strictExternal
+}
+
+// StrictPriority returns true if external values should be applied only through priority resolution
+func (o *Options) StrictPriority() bool {
+	return o.strictPriority
+}
+
+// SharedEnv returns true if environment variable can be used by several fields
+func (o *Options) SharedEnv() bool {
+	return o.sharedEnv
+}
+
+// ReloadSignals returns signals that trigger configuration reload
+func (o *Options) ReloadSignals() []os.Signal {
+	return o.reloadSignals
+}
+
+func (o *Options) OnReload(arg ReloadArg) {
+	for _, s := range o.reloadSubscribers {
+		s(arg)
+	}
+}
+
+// CheckUnknownKeys returns true if unknown configuration keys should be reported
+func (o *Options) CheckUnknownKeys() bool {
+	return o.strictExternal || o.onUnknownKey != nil
+}
+
+func (o *Options) OnUnknownKey(arg UnknownKeyArg) {
+	if o.onUnknownKey != nil {
+		o.onUnknownKey(arg)
+	}
+}
+
+// EnvCheck returns prefix of environment variables that should match fields.
+// Returns false if check is disabled
+func (o *Options) EnvCheck() (prefix string, strict bool, ok bool) {
+	if o.envCheck == nil {
+		return "", false, false
+	}
+	return o.envCheck.prefix, o.envCheck.strict, true
+}
+
+// IsSecret returns true if field with name should be treated as secret
+func (o *Options) IsSecret(name string) bool {
+	return o.secretName != nil && o.secretName(name)
+}
+
+// HasSourcePolicy returns true if source policy is set
+func (o *Options) HasSourcePolicy() bool {
+	return o.sourcePolicy != nil
+}
+
+// AllowedSources returns sources allowed for the field by the source policy.
+// Returns nil if all sources are allowed
+func (o *Options) AllowedSources(arg FieldInitializedArg) []ConfigSource {
+	if o.sourcePolicy == nil {
+		return nil
+	}
+	return o.sourcePolicy(arg)
+}
+
+// Providers returns providers of external data in the order of options
+func (o *Options) Providers() []external.Provider {
+	return o.providers
+}
+
+// LoadConcurrency returns number of providers loaded at once
+func (o *Options) LoadConcurrency() int {
+	if o.loadConcurrency <= 0 {
+		return defaultLoadConcurrency
+	}
+	return o.loadConcurrency
+}
@@ -36,3 +36,19 @@ func TestOptions_DefaultPriorityOrder_Ok(t *testing.T) {
 		t.Fatal("unexpected result:", opts.PriorityOrder())
 	}
 }
+
+func TestOptions_Clone_Ok(t *testing.T) {
+	opts := &Options{}
+	WithPriorityOrder(EnvVariable, FlagVariable).Apply(opts)
+	WithReloadSubscriber(func(ReloadArg) {}).Apply(opts)
+	c := opts.Clone()
+	WithStrictPriority().Apply(c)
+	WithReloadSubscriber(func(ReloadArg) {}).Apply(c)
+	c.priorityOrder[0] = DefaultValue
+	if opts.StrictPriority() || len(opts.reloadSubscribers) != 1 || opts.PriorityOrder()[0] != EnvVariable {
+		t.Fatal("options are changed by the clone")
+	}
+	if !c.StrictPriority() || len(c.reloadSubscribers) != 2 {
+		t.Fatal("unexpected clone")
+	}
+}
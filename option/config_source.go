@@ -1,5 +1,10 @@
 package option
 
+import (
+	"fmt"
+	"strings"
+)
+
 type ConfigSource int
 
 const (
@@ -24,6 +29,39 @@ func (s ConfigSource) String() string {
 	return ""
 }
 
+// ParseConfigSources parses comma-separated list of sources.
+// Supported names are flag, env, external and default (case-insensitive)
+func ParseConfigSources(s string) ([]ConfigSource, error) {
+	var result []ConfigSource
+	for _, name := range strings.Split(s, ",") {
+		name = strings.TrimSpace(name)
+		var cs ConfigSource
+		switch strings.ToLower(name) {
+		case "flag":
+			cs = FlagVariable
+		case "env", "environment":
+			cs = EnvVariable
+		case "external":
+			cs = ExternalSource
+		case "default":
+			cs = DefaultValue
+		default:
+			return nil, fmt.Errorf("unknown config source %q", name)
+		}
+		result = append(result, cs)
+	}
+	return result, nil
+}
+
+// FormatPriorityOrder returns names of sources in the priority order, e.g. "Flag > Environment"
+func FormatPriorityOrder(order []ConfigSource) string {
+	names := make([]string, len(order))
+	for i, cs := range order {
+		names[i] = cs.String()
+	}
+	return strings.Join(names, " > ")
+}
+
 type priorityOrder []ConfigSource
 
 func (p priorityOrder) Apply(opts *Options) {
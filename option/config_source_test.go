@@ -94,3 +94,26 @@ func TestWithPriorityOrder_InvalidSourceAndValid_Ok(t *testing.T) {
 		t.Fatal("unexpected result: ", opts.PriorityOrder())
 	}
 }
+
+func TestParseConfigSources_Ok(t *testing.T) {
+	s, err := ParseConfigSources("flag, Env,EXTERNAL,default")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(s, []ConfigSource{FlagVariable, EnvVariable, ExternalSource, DefaultValue}) {
+		t.Fatal("unexpected result: ", s)
+	}
+}
+
+func TestParseConfigSources_Err(t *testing.T) {
+	if _, err := ParseConfigSources("env,file"); err == nil {
+		t.Fatal("expected error but got nil")
+	}
+}
+
+func TestFormatPriorityOrder_Ok(t *testing.T) {
+	s := FormatPriorityOrder([]ConfigSource{ExternalSource, EnvVariable, FlagVariable})
+	if s != "External > Environment > Flag" {
+		t.Fatal("unexpected result: ", s)
+	}
+}
@@ -0,0 +1,22 @@
+package option
+
+type envCheck struct {
+	prefix string
+	strict bool
+}
+
+func (o envCheck) Apply(opts *Options) {
+	opts.envCheck = &o
+}
+
+// WithEnvCheck reports environment variables that start with prefix
+// but don't match any field to the option.WithUnknownKeyHook callback
+func WithEnvCheck(prefix string) ClientOption {
+	return envCheck{prefix: prefix}
+}
+
+// WithStrictEnv fails parsing if there are environment variables that start with prefix
+// but don't match any field
+func WithStrictEnv(prefix string) ClientOption {
+	return envCheck{prefix: prefix, strict: true}
+}
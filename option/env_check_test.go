@@ -0,0 +1,19 @@
+package option
+
+import "testing"
+
+func TestWithEnvCheck_Ok(t *testing.T) {
+	opts := &Options{}
+	if _, _, ok := opts.EnvCheck(); ok {
+		t.Fatal("check enabled by default")
+	}
+	WithEnvCheck("APP_").Apply(opts)
+	prefix, strict, ok := opts.EnvCheck()
+	if !ok || strict || prefix != "APP_" {
+		t.Fatal("unexpected result: ", prefix, strict, ok)
+	}
+	WithStrictEnv("APP_").Apply(opts)
+	if _, strict, _ = opts.EnvCheck(); !strict {
+		t.Fatal("strict mode is not enabled")
+	}
+}
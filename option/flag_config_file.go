@@ -4,27 +4,85 @@ import (
 	"flag"
 	"fmt"
 	"os"
+	"sync"
 
 	"github.com/antonmashko/envconf/external"
 )
 
+// ConfigFile is implemented by external sources that are read from file.
+// Used for watching file changes
+type ConfigFile interface {
+	// Path of the configuration file
+	Path() string
+	// Reload reads configuration file again
+	Reload() error
+}
+
 type withExternalConfigFileOption struct {
-	external.External
-	fpOpt flagParsedFunc
+	// External is replaced by Reload. Option is shared by Parse calls, so it's guarded by mu
+	External external.External
+	mu       sync.RWMutex
+	fpOpt    flagParsedFunc
+	path     *string
+	initConf func([]byte) (external.External, error)
+}
+
+// current returns the last read external source
+func (o *withExternalConfigFileOption) current() external.External {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+	return o.External
 }
 
 func (o *withExternalConfigFileOption) TagName() []string {
-	if o.External == nil {
+	ext := o.current()
+	if ext == nil {
 		return []string{}
 	}
-	return o.External.TagName()
+	return ext.TagName()
 }
 
 func (o *withExternalConfigFileOption) Unmarshal(v interface{}) error {
-	if o.External == nil {
+	ext := o.current()
+	if ext == nil {
 		return nil
 	}
-	return o.External.Unmarshal(v)
+	return ext.Unmarshal(v)
+}
+
+func (o *withExternalConfigFileOption) DecodeTree() (interface{}, func(interface{}) error, error) {
+	ext := o.current()
+	if ext == nil {
+		return nil, func(interface{}) error { return nil }, nil
+	}
+	return external.AsTreeDecoder(ext).DecodeTree()
+}
+
+func (o *withExternalConfigFileOption) DecodePositions() (interface{}, func([]string) (int, int, bool), func(interface{}) error, error) {
+	ext := o.current()
+	if ext == nil {
+		return nil, nil, func(interface{}) error { return nil }, nil
+	}
+	return external.AsPositionDecoder(ext).DecodePositions()
+}
+
+func (o *withExternalConfigFileOption) Path() string {
+	return *o.path
+}
+
+func (o *withExternalConfigFileOption) Reload() error {
+	b, err := os.ReadFile(*o.path)
+	if err != nil {
+		return fmt.Errorf("os.ReadFile: %w", err)
+	}
+	ext, err := o.initConf(b)
+	if err != nil {
+		return err
+	}
+	o.mu.Lock()
+	o.External = ext
+	o.mu.Unlock()
+	return nil
 }
 
 func (o *withExternalConfigFileOption) Apply(opts *Options) {
@@ -35,18 +93,10 @@ func (o *withExternalConfigFileOption) Apply(opts *Options) {
 // WithFlagConfigFile wraps option.WithFlagParsed with reading configuration file from flag defined path
 func WithFlagConfigFile(flagName string, flagValue string, flagDescription string, initConf func([]byte) (external.External, error)) ClientOption {
 	cfg := flag.String(flagName, flagValue, flagDescription)
-	opt := &withExternalConfigFileOption{}
-	opt.fpOpt = func() error {
-		b, err := os.ReadFile(*cfg)
-		if err != nil {
-			return fmt.Errorf("os.ReadFile: %w", err)
-		}
-		ext, err := initConf(b)
-		if err != nil {
-			return err
-		}
-		opt.External = ext
-		return nil
+	opt := &withExternalConfigFileOption{
+		path:     cfg,
+		initConf: initConf,
 	}
+	opt.fpOpt = opt.Reload
 	return opt
 }
@@ -88,3 +88,37 @@ func TestWithFlagConfigFile_JsonExternal_Ok(t *testing.T) {
 		t.Fatal("opt.Unmarshal error not nil")
 	}
 }
+
+func TestWithFlagConfigFile_Reload_Ok(t *testing.T) {
+	f, err := os.CreateTemp("", "envconf.tmp")
+	if err != nil {
+		t.Fatal("os.Create:", err)
+	}
+	defer f.Close()
+	defer os.Remove(f.Name())
+	opt := WithFlagConfigFile("config4", f.Name(), "", func(b []byte) (external.External, error) {
+		return json.Json(b), nil
+	})
+	cf, ok := opt.(ConfigFile)
+	if !ok {
+		t.Fatal("option doesn't implement ConfigFile")
+	}
+	if cf.Path() != f.Name() {
+		t.Fatal("unexpected path: ", cf.Path())
+	}
+	if err = os.WriteFile(f.Name(), []byte(`{"foo":"baz"}`), 0o600); err != nil {
+		t.Fatal("os.WriteFile: ", err)
+	}
+	if err = cf.Reload(); err != nil {
+		t.Fatal("cf.Reload(): ", err)
+	}
+	opts := &Options{}
+	opt.Apply(opts)
+	result := struct {
+		Foo string `json:"foo"`
+	}{}
+	opts.External().Unmarshal(&result)
+	if result.Foo != "baz" {
+		t.Fatal("unexpected result: ", result)
+	}
+}
@@ -29,6 +29,12 @@ func (h *help) printValue(f FieldInitializedArg) {
 	fmt.Fprintf(h.out, "\tflag: %s\n", f.FlagName)
 	fmt.Fprintf(h.out, "\tenvironment variable: %s\n", f.EnvName)
 	fmt.Fprintf(h.out, "\trequired: %t\n", f.Required)
+	if len(f.PriorityOrder) != 0 {
+		fmt.Fprintf(h.out, "\tpriority: %s\n", FormatPriorityOrder(f.PriorityOrder))
+	}
+	if f.Secret {
+		fmt.Fprintln(h.out, "\tsecret: true")
+	}
 	if f.Description != "" {
 		fmt.Fprintf(h.out, "\tdescription: \"%s\"\n", f.Description)
 	}
@@ -48,3 +48,24 @@ func TestWithoutCustomUsage_Ok(t *testing.T) {
 		t.Fatal("opts.onFieldInitialized or opts.Usage() is not nil")
 	}
 }
+
+func TestHelp_PrintSecret_Ok(t *testing.T) {
+	buff := bytes.NewBuffer([]byte{})
+	h := &help{out: buff}
+	h.addField(FieldInitializedArg{
+		Name:          "Password",
+		FullName:      "DB.Password",
+		Type:          reflect.TypeOf(""),
+		FlagName:      "-",
+		EnvName:       "DB_PASSWORD",
+		DefaultValue:  SecretMask,
+		Secret:        true,
+		PriorityOrder: []ConfigSource{EnvVariable, DefaultValue},
+	})
+	h.print()
+	const expected = "DB.Password <string> ******\n\tflag: -\n\tenvironment variable: DB_PASSWORD\n" +
+		"\trequired: false\n\tpriority: Environment > Default\n\tsecret: true\n\n"
+	if buff.String() != expected {
+		t.Fatalf("unexpected result: %q", buff.String())
+	}
+}
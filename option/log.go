@@ -5,6 +5,7 @@ import (
 	"log"
 	"regexp"
 	"strings"
+	"sync"
 )
 
 type Printer interface {
@@ -17,12 +18,34 @@ type Logger struct {
 	HideSecrets bool
 	// Regex for match secrets by field name in lower case
 	SecretMatchRegex string
+
+	mu sync.Mutex
+	re *regexp.Regexp
+}
+
+// secretRegexp returns compiled SecretMatchRegex. Regex is compiled again only if SecretMatchRegex was changed
+func (l *Logger) secretRegexp() *regexp.Regexp {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.re == nil || l.re.String() != l.SecretMatchRegex {
+		l.re = regexp.MustCompile(l.SecretMatchRegex)
+	}
+	return l.re
+}
+
+func (l *Logger) isSecret(name string) bool {
+	return l.HideSecrets && l.secretRegexp().MatchString(strings.ToLower(name))
 }
 
 func (l *Logger) printDefined(arg FieldDefinedArg) {
 	var v interface{} = arg.Value
-	if l.HideSecrets && regexp.MustCompile(l.SecretMatchRegex).MatchString(strings.ToLower(arg.Name)) {
-		v = "******"
+	if arg.Secret || l.isSecret(arg.Name) {
+		v = SecretMask
+	}
+	if arg.Position.IsValid() {
+		l.Print("field=\"", arg.FullName, "\" value=\"", v, "\" type=\"", arg.Type.String(),
+			"\" source=\"", arg.Source.String(), "\" position=\"", arg.Position, "\"")
+		return
 	}
 	l.Print("field=\"", arg.FullName, "\" value=\"", v, "\" type=\"", arg.Type.String(),
 		"\" source=\"", arg.Source.String(), "\"")
@@ -35,6 +58,10 @@ func (l *Logger) printErr(arg FieldDefineErrorArg) {
 func (l *Logger) Apply(opts *Options) {
 	opts.onFieldDefined = l.printDefined
 	opts.onFieldDefineErr = l.printErr
+	if l.HideSecrets {
+		// secret fields are also redacted in errors and other hooks
+		opts.secretName = l.isSecret
+	}
 }
 
 // SetLogger define printer.
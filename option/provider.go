@@ -0,0 +1,32 @@
+package option
+
+import "github.com/antonmashko/envconf/external"
+
+// defaultLoadConcurrency is a number of providers loaded at once by default
+const defaultLoadConcurrency = 4
+
+type providerOpt struct {
+	p external.Provider
+}
+
+func (o providerOpt) Apply(opts *Options) {
+	opts.providers = append(opts.providers, o.p)
+}
+
+// WithProvider adds provider of external data, e.g. remote configuration service.
+// Providers are loaded concurrently with the context of EnvConf.ParseContext and loaded again on reload.
+// Data of providers is merged over external source of WithExternal in the order of options
+func WithProvider(p external.Provider) ClientOption {
+	return providerOpt{p: p}
+}
+
+type loadConcurrency int
+
+func (n loadConcurrency) Apply(opts *Options) {
+	opts.loadConcurrency = int(n)
+}
+
+// WithLoadConcurrency limits number of providers loaded at once. Default: 4
+func WithLoadConcurrency(n int) ClientOption {
+	return loadConcurrency(n)
+}
@@ -0,0 +1,60 @@
+package option
+
+import (
+	"os"
+)
+
+// FieldChange describes a field that got a different value or source on reload
+type FieldChange struct {
+	Name     string
+	FullName string
+	// Reloadable is true if field is marked with reload tag.
+	// Changes of other fields reject the reload
+	Reloadable bool
+	// Secret is true if field holds sensitive value. OldValue and NewValue are redacted for such fields
+	Secret bool
+
+	OldValue  interface{}
+	OldSource ConfigSource
+	NewValue  interface{}
+	NewSource ConfigSource
+}
+
+// ReloadArg is a result of configuration reload
+type ReloadArg struct {
+	// Config is a new configuration of the same type as data passed to EnvConf.Parse.
+	// Nil if reload failed
+	Config interface{}
+	// Changes of the fields compared to the previous resolution
+	Changes []FieldChange
+	// Err is a reason of failed reload. Previous configuration stays in effect
+	Err error
+}
+
+type reloadSignal []os.Signal
+
+func (s reloadSignal) Apply(opts *Options) {
+	opts.reloadSignals = s
+}
+
+// WithReloadSignal reloads configuration when process receives one of the signals
+// (syscall.SIGHUP by default on unix, other platforms don't have default signal).
+// Every external source is read again, values of flags and environment variables are kept.
+// Use option.WithReloadSubscriber for receiving new configuration
+func WithReloadSignal(sig ...os.Signal) ClientOption {
+	if len(sig) == 0 {
+		sig = defaultReloadSignals
+	}
+	return reloadSignal(sig)
+}
+
+type reloadSubscriber func(ReloadArg)
+
+func (s reloadSubscriber) Apply(opts *Options) {
+	opts.reloadSubscribers = append(opts.reloadSubscribers, s)
+}
+
+// WithReloadSubscriber adds callback that is called after every reload attempt
+func WithReloadSubscriber(fn func(ReloadArg)) ClientOption {
+	return reloadSubscriber(fn)
+}
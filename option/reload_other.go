@@ -0,0 +1,8 @@
+//go:build !unix
+
+package option
+
+import "os"
+
+// there is no SIGHUP, so configuration isn't reloaded without specified signals
+var defaultReloadSignals []os.Signal
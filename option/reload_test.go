@@ -0,0 +1,15 @@
+package option
+
+import "testing"
+
+func TestWithReloadSubscriber_Ok(t *testing.T) {
+	opts := &Options{}
+	opts.OnReload(ReloadArg{})
+	var calls int
+	WithReloadSubscriber(func(ReloadArg) { calls++ }).Apply(opts)
+	WithReloadSubscriber(func(ReloadArg) { calls++ }).Apply(opts)
+	opts.OnReload(ReloadArg{})
+	if calls != 2 {
+		t.Fatalf("unexpected number of calls: %d", calls)
+	}
+}
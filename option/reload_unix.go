@@ -0,0 +1,10 @@
+//go:build unix
+
+package option
+
+import (
+	"os"
+	"syscall"
+)
+
+var defaultReloadSignals = []os.Signal{syscall.SIGHUP}
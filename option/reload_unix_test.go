@@ -0,0 +1,23 @@
+//go:build unix
+
+package option
+
+import (
+	"os"
+	"syscall"
+	"testing"
+)
+
+func TestWithReloadSignal_Default_Ok(t *testing.T) {
+	opts := &Options{}
+	WithReloadSignal().Apply(opts)
+	sigs := opts.ReloadSignals()
+	if len(sigs) != 1 || sigs[0] != syscall.SIGHUP {
+		t.Fatalf("unexpected signals: %v", sigs)
+	}
+	WithReloadSignal(os.Interrupt).Apply(opts)
+	sigs = opts.ReloadSignals()
+	if len(sigs) != 1 || sigs[0] != os.Interrupt {
+		t.Fatalf("unexpected signals: %v", sigs)
+	}
+}
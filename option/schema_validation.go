@@ -0,0 +1,19 @@
+package option
+
+import "github.com/antonmashko/envconf/jsonschema"
+
+type schemaValidation struct {
+	s *jsonschema.Schema
+}
+
+func (o schemaValidation) Apply(opts *Options) {
+	opts.schemaValidation = true
+	opts.schema = o.s
+}
+
+// WithSchemaValidation validates external configuration against JSON Schema before mapping it into struct.
+// If s is nil, schema is generated from the parsed struct (see: envconf.JSONSchema)
+// without `required` keywords, because required field can be defined from other sources
+func WithSchemaValidation(s *jsonschema.Schema) ClientOption {
+	return schemaValidation{s: s}
+}
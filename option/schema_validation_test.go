@@ -0,0 +1,20 @@
+package option
+
+import (
+	"testing"
+
+	"github.com/antonmashko/envconf/jsonschema"
+)
+
+func TestWithSchemaValidation_Ok(t *testing.T) {
+	opts := &Options{}
+	if _, ok := opts.SchemaValidation(); ok {
+		t.Fatal("validation enabled by default")
+	}
+	s := &jsonschema.Schema{}
+	WithSchemaValidation(s).Apply(opts)
+	rs, ok := opts.SchemaValidation()
+	if !ok || rs != s {
+		t.Fatal("unexpected result: ", rs, ok)
+	}
+}
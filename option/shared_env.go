@@ -0,0 +1,13 @@
+package option
+
+type sharedEnv struct{}
+
+func (sharedEnv) Apply(opts *Options) {
+	opts.sharedEnv = true
+}
+
+// WithSharedEnv allows several fields to be defined by the same environment variable, they share its value.
+// By default parsing fails if environment variable is used by more than one field
+func WithSharedEnv() ClientOption {
+	return sharedEnv{}
+}
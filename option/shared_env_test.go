@@ -0,0 +1,14 @@
+package option
+
+import "testing"
+
+func TestWithSharedEnv_Ok(t *testing.T) {
+	opts := &Options{}
+	if opts.SharedEnv() {
+		t.Fatal("shared env is enabled by default")
+	}
+	WithSharedEnv().Apply(opts)
+	if !opts.SharedEnv() {
+		t.Fatal("shared env is not enabled")
+	}
+}
@@ -0,0 +1,27 @@
+package option
+
+// SourcePolicy returns sources that are allowed for the field.
+// nil result allows all sources
+type SourcePolicy func(FieldInitializedArg) []ConfigSource
+
+func (p SourcePolicy) Apply(opts *Options) {
+	opts.sourcePolicy = p
+}
+
+// WithSourcePolicy restricts sources of every field with policy p.
+// Policy is applied in addition to the sources tag.
+// Parsing fails if restricted source provides value for the field
+func WithSourcePolicy(p SourcePolicy) ClientOption {
+	return p
+}
+
+// WithSecretSources allows only sources s for secret fields.
+// e.g. option.WithSecretSources(option.EnvVariable, option.ExternalSource) prevents secrets from flags
+func WithSecretSources(s ...ConfigSource) ClientOption {
+	return SourcePolicy(func(arg FieldInitializedArg) []ConfigSource {
+		if arg.Secret {
+			return s
+		}
+		return nil
+	})
+}
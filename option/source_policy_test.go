@@ -0,0 +1,31 @@
+package option
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestWithSourcePolicy_Ok(t *testing.T) {
+	opts := &Options{}
+	if opts.AllowedSources(FieldInitializedArg{}) != nil {
+		t.Fatal("sources are restricted without policy")
+	}
+	WithSourcePolicy(func(arg FieldInitializedArg) []ConfigSource {
+		return []ConfigSource{EnvVariable}
+	}).Apply(opts)
+	if !reflect.DeepEqual(opts.AllowedSources(FieldInitializedArg{}), []ConfigSource{EnvVariable}) {
+		t.Fatal("unexpected result: ", opts.AllowedSources(FieldInitializedArg{}))
+	}
+}
+
+func TestWithSecretSources_Ok(t *testing.T) {
+	opts := &Options{}
+	WithSecretSources(EnvVariable, ExternalSource).Apply(opts)
+	if opts.AllowedSources(FieldInitializedArg{Name: "Host"}) != nil {
+		t.Fatal("sources of not secret field are restricted")
+	}
+	s := opts.AllowedSources(FieldInitializedArg{Name: "Password", Secret: true})
+	if !reflect.DeepEqual(s, []ConfigSource{EnvVariable, ExternalSource}) {
+		t.Fatal("unexpected result: ", s)
+	}
+}
@@ -0,0 +1,38 @@
+package option
+
+import "github.com/antonmashko/envconf/external"
+
+// UnknownKeyArg describes configuration key that doesn't match any field
+type UnknownKeyArg struct {
+	Source ConfigSource
+	// Key is a full path of the key in the external source
+	Key string
+	// Suggestion is the closest known key. Empty if nothing similar found
+	Suggestion string
+	// Position of the key value in the external source. Invalid if it's unknown
+	Position external.Position
+}
+
+type strictExternal struct{}
+
+func (strictExternal) Apply(opts *Options) {
+	opts.strictExternal = true
+}
+
+// WithStrictExternal fails parsing if external source contains keys
+// that don't match any struct field
+func WithStrictExternal() ClientOption {
+	return strictExternal{}
+}
+
+type unknownKeyHook func(UnknownKeyArg)
+
+func (f unknownKeyHook) Apply(opts *Options) {
+	opts.onUnknownKey = f
+}
+
+// WithUnknownKeyHook calls f for every configuration key that doesn't match any struct field.
+// Without option.WithStrictExternal unknown keys don't fail parsing
+func WithUnknownKeyHook(f func(UnknownKeyArg)) ClientOption {
+	return unknownKeyHook(f)
+}
@@ -0,0 +1,29 @@
+package option
+
+import "testing"
+
+func TestWithStrictExternal_Ok(t *testing.T) {
+	opts := &Options{}
+	if opts.StrictExternal() || opts.CheckUnknownKeys() {
+		t.Fatal("strict mode enabled by default")
+	}
+	WithStrictExternal().Apply(opts)
+	if !opts.StrictExternal() || !opts.CheckUnknownKeys() {
+		t.Fatal("strict mode is not enabled")
+	}
+}
+
+func TestWithUnknownKeyHook_Ok(t *testing.T) {
+	opts := &Options{}
+	var arg UnknownKeyArg
+	WithUnknownKeyHook(func(a UnknownKeyArg) {
+		arg = a
+	}).Apply(opts)
+	if opts.StrictExternal() || !opts.CheckUnknownKeys() {
+		t.Fatal("unexpected mode")
+	}
+	opts.OnUnknownKey(UnknownKeyArg{Key: "foo"})
+	if arg.Key != "foo" {
+		t.Fatal("hook is not invoked")
+	}
+}
@@ -0,0 +1,15 @@
+package option
+
+type strictPriority struct{}
+
+func (strictPriority) Apply(opts *Options) {
+	opts.strictPriority = true
+}
+
+// WithStrictPriority applies values of external source only to the fields
+// where external source wins according to the priority order.
+// By default external source is unmarshaled directly into the struct before priority resolution,
+// so external values can remain in fields that are defined by other sources or not defined at all
+func WithStrictPriority() ClientOption {
+	return strictPriority{}
+}
@@ -0,0 +1,14 @@
+package option
+
+import "testing"
+
+func TestWithStrictPriority_Ok(t *testing.T) {
+	opts := &Options{}
+	if opts.StrictPriority() {
+		t.Fatal("strict priority is enabled by default")
+	}
+	WithStrictPriority().Apply(opts)
+	if !opts.StrictPriority() {
+		t.Fatal("strict priority is not enabled")
+	}
+}
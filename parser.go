@@ -1,14 +1,30 @@
 package envconf
 
 import (
+	"context"
 	"flag"
+	"sync"
 
-	"github.com/antonmashko/envconf/external"
 	"github.com/antonmashko/envconf/option"
 )
 
+// EnvConf parses configuration into structs. EnvConf is safe for concurrent use:
+// options are applied to the copy of EnvConf options for each Parse call
 type EnvConf struct {
-	opts *option.Options
+	opts    *option.Options
+	flagSet *flag.FlagSet
+
+	mu sync.Mutex
+	// flagSources are flags registered in the flag set by name.
+	// Registered flags are reused by following Parse calls
+	flagSources map[string]*flagSource
+	// last is a resolution of the last successful Parse call or reload
+	last *resolver
+	// value receives configuration of Parse and reloads if Value is passed as data
+	value      publisher
+	stopSignal func()
+	// reloadMu serializes reloads started by watcher and signals
+	reloadMu sync.Mutex
 }
 
 func New() *EnvConf {
@@ -17,95 +33,103 @@ func New() *EnvConf {
 	}
 }
 
-func (e *EnvConf) fieldInitialized(f field) {
-	cf := asConfigField(f)
-	if cf == nil {
-		return
+// flags returns flag set for registering fields flags.
+// flag.CommandLine is used by default
+func (e *EnvConf) flags() *flag.FlagSet {
+	if e.flagSet == nil {
+		return flag.CommandLine
 	}
-	dv, _ := cf.configuration.defaultValue.Value()
-	e.opts.OnFieldInitialized(option.FieldInitializedArg{
-		Name:         cf.name(),
-		FullName:     cf.fullName(),
-		Type:         cf.StructField.Type,
-		Required:     cf.property.required,
-		Description:  cf.property.description,
-		FlagName:     cf.configuration.flag.Name(),
-		EnvName:      cf.configuration.env.Name(),
-		DefaultValue: dv,
-	})
+	return e.flagSet
 }
 
-func (e *EnvConf) fieldDefined(f field) {
-	cf := asConfigField(f)
-	if cf == nil || !cf.isSet() {
-		return
+// registerFlag defines flag of the field in the flag set.
+// Returns already registered flag source with the same name if it exists
+func (e *EnvConf) registerFlag(s *flagSource, usage string) (*flagSource, error) {
+	if s.name == tagIgnored {
+		return s, nil
 	}
-	dv, _ := cf.configuration.defaultValue.Value()
-	e.opts.OnFieldDefined(option.FieldDefinedArg{
-		Name:         cf.name(),
-		FullName:     cf.fullName(),
-		Type:         cf.StructField.Type,
-		Required:     cf.property.required,
-		Description:  cf.property.description,
-		FlagName:     cf.configuration.flag.Name(),
-		EnvName:      cf.configuration.env.Name(),
-		DefaultValue: dv,
-		Value:        cf.value,
-		Source:       cf.source,
-	})
-}
-
-func (e *EnvConf) fieldNotDefined(f field, err error) {
-	cf := asConfigField(f)
-	if cf == nil {
-		return
+	flagMu.Lock()
+	defer flagMu.Unlock()
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if fs, ok := e.flagSources[s.name]; ok {
+		return fs, nil
+	}
+	if err := s.register(e.flags(), usage); err != nil {
+		return nil, err
+	}
+	if e.flagSources == nil {
+		e.flagSources = make(map[string]*flagSource)
 	}
-	e.opts.OnFieldDefineErr(option.FieldDefineErrorArg{
-		Name:     cf.name(),
-		FullName: cf.fullName(),
-		Err:      err,
-	})
+	e.flagSources[s.name] = s
+	return s, nil
 }
 
 // Parse define variables inside data from different sources,
 // such as flag/environment variable or default value
 func (e *EnvConf) Parse(data interface{}, opts ...option.ClientOption) error {
+	return e.ParseContext(context.Background(), data, opts...)
+}
+
+// ParseContext works as Parse, ctx is passed to providers of external data (see option.WithProvider).
+// If ctx is done before providers are loaded, parsing fails with *LoadError of every stalled provider
+func (e *EnvConf) ParseContext(ctx context.Context, data interface{}, opts ...option.ClientOption) error {
 	if data == nil {
 		return ErrNilData
 	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	o := e.opts.Clone()
 	// enable help output
-	option.WithCustomUsage().Apply(e.opts)
+	option.WithCustomUsage().Apply(o)
 	for i := range opts {
-		opts[i].Apply(e.opts)
+		opts[i].Apply(o)
 	}
 
-	extMapper := external.NewExternalConfigMapper(e.opts.External())
-	p, err := newParentStructType(data, e)
+	r := newResolver(e, o)
+	r.ctx = ctx
+	pub, ok := data.(publisher)
+	if ok {
+		data = pub.draft()
+	}
+	p, err := newParentStructType(data, r)
 	if err != nil {
 		return err
 	}
-	if err = p.init(); err != nil {
+	if err = r.parse(p, false); err != nil {
+		// resolution of the previous successful call is kept
 		return err
 	}
-	if e.opts.Usage() != nil {
-		flag.Usage = e.opts.Usage()
-	}
-	flag.Parse()
-	if fp := e.opts.FlagParsed(); fp != nil {
-		if err = fp(); err != nil {
-			return err
-		}
+	e.mu.Lock()
+	e.last, e.value = r, pub
+	e.mu.Unlock()
+	if pub != nil {
+		pub.publish(data)
 	}
-	if err = extMapper.Unmarshal(data); err != nil {
-		return err
+	e.handleReloadSignals(o)
+	return nil
+}
+
+// Report returns resolution report of the last successful Parse call.
+// Returns nil if Parse wasn't called successfully
+func (e *EnvConf) Report() *Report {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.last == nil {
+		return nil
 	}
-	p.ext = extMapper.Data()
-	return p.define()
+	return e.last.resolutionReport()
 }
 
-// PriorityOrder return parsing priority order
+// PriorityOrder return parsing priority order of the last successful Parse call
 func (e *EnvConf) PriorityOrder() []option.ConfigSource {
-	return e.opts.PriorityOrder()
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.last == nil {
+		return e.opts.PriorityOrder()
+	}
+	return e.last.opts.PriorityOrder()
 }
 
 // Parse define variables inside data from different sources,
@@ -113,3 +137,8 @@ func (e *EnvConf) PriorityOrder() []option.ConfigSource {
 func Parse(data interface{}, opts ...option.ClientOption) error {
 	return New().Parse(data, opts...)
 }
+
+// ParseContext define variables inside data from different sources with ctx passed to providers of external data
+func ParseContext(ctx context.Context, data interface{}, opts ...option.ClientOption) error {
+	return New().ParseContext(ctx, data, opts...)
+}
@@ -0,0 +1,68 @@
+package envconf_test
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/antonmashko/envconf"
+	"github.com/antonmashko/envconf/option"
+)
+
+// largeStructType returns struct type with n nested structs of 10 fields each
+func largeStructType(n int) reflect.Type {
+	inner := make([]reflect.StructField, 10)
+	for i := range inner {
+		inner[i] = reflect.StructField{
+			Name: fmt.Sprintf("Field%d", i),
+			Type: reflect.TypeOf(0),
+			Tag:  reflect.StructTag(fmt.Sprintf(`env:"*" default:"%d" description:"field %d"`, i, i)),
+		}
+	}
+	innerType := reflect.StructOf(inner)
+	fields := make([]reflect.StructField, n)
+	for i := range fields {
+		fields[i] = reflect.StructField{
+			Name: fmt.Sprintf("Inner%d", i),
+			Type: innerType,
+		}
+	}
+	return reflect.StructOf(fields)
+}
+
+func benchmarkParseLarge(b *testing.B, n int) {
+	rt := largeStructType(n)
+	ec := envconf.New()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		data := reflect.New(rt).Interface()
+		if err := ec.Parse(data, option.WithoutCustomUsage()); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkParse_Large100(b *testing.B) {
+	benchmarkParseLarge(b, 10)
+}
+
+func BenchmarkParse_Large1000(b *testing.B) {
+	benchmarkParseLarge(b, 100)
+}
+
+func BenchmarkParse_Parallel(b *testing.B) {
+	rt := largeStructType(10)
+	ec := envconf.New()
+	b.ReportAllocs()
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			data := reflect.New(rt).Interface()
+			if err := ec.Parse(data, option.WithoutCustomUsage()); err != nil {
+				b.Error(err)
+				return
+			}
+		}
+	})
+}
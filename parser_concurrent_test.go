@@ -0,0 +1,64 @@
+package envconf_test
+
+import (
+	"fmt"
+	"os"
+	"sync"
+	"testing"
+
+	"github.com/antonmashko/envconf"
+	jsonconf "github.com/antonmashko/envconf/external/json"
+	"github.com/antonmashko/envconf/option"
+)
+
+func TestConcurrentParse_Ok(t *testing.T) {
+	type config struct {
+		ID    int    `json:"id"`
+		Name  string `json:"name" default:"default-name"`
+		Level string `flag:"test-concurrent-parse-level" env:"TEST_CONCURRENT_PARSE_LEVEL" default:"info"`
+		Inner struct {
+			Port int `json:"port" env:"*"`
+		} `json:"inner" envconf:"test_concurrent_parse"`
+	}
+	os.Setenv("TEST_CONCURRENT_PARSE_PORT", "8080")
+	defer os.Unsetenv("TEST_CONCURRENT_PARSE_PORT")
+	ec := envconf.New()
+	var wg sync.WaitGroup
+	for i := 0; i < 16; i++ {
+		wg.Add(1)
+		go func(id int) {
+			defer wg.Done()
+			var cfg config
+			ext := jsonconf.Json(fmt.Sprintf(`{"id": %d, "inner": {"port": 80}}`, id))
+			if err := ec.Parse(&cfg, option.WithExternal(ext), option.WithoutCustomUsage()); err != nil {
+				t.Error(err)
+				return
+			}
+			if cfg.ID != id || cfg.Name != "default-name" || cfg.Level != "info" || cfg.Inner.Port != 8080 {
+				t.Errorf("unexpected result: %#v", cfg)
+			}
+		}(i)
+	}
+	wg.Wait()
+}
+
+func TestConcurrentParse_OptionsPerCall_Ok(t *testing.T) {
+	type config struct {
+		Name string `json:"name"`
+	}
+	ec := envconf.New()
+	var cfg config
+	if err := ec.Parse(&cfg, option.WithExternal(jsonconf.Json(`{"name": "from-json"}`))); err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Name != "from-json" {
+		t.Fatalf("unexpected result: %#v", cfg)
+	}
+	cfg = config{}
+	if err := ec.Parse(&cfg); err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Name != "" {
+		t.Fatalf("options of the previous call are applied: %#v", cfg)
+	}
+}
@@ -0,0 +1,177 @@
+package envconf_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/antonmashko/envconf"
+	"github.com/antonmashko/envconf/external"
+	jsonconf "github.com/antonmashko/envconf/external/json"
+	"github.com/antonmashko/envconf/option"
+)
+
+type funcProvider struct {
+	name string
+	load func(ctx context.Context) (external.External, error)
+}
+
+func (p funcProvider) Name() string {
+	return p.name
+}
+
+func (p funcProvider) Load(ctx context.Context) (external.External, error) {
+	return p.load(ctx)
+}
+
+func jsonProvider(name, data string) funcProvider {
+	return funcProvider{name: name, load: func(context.Context) (external.External, error) {
+		return jsonconf.Json(data), nil
+	}}
+}
+
+type contextConfig struct {
+	Name string `json:"name"`
+	DB   struct {
+		Host string `json:"host"`
+		Port int    `json:"port"`
+	} `json:"db"`
+}
+
+func TestParseContext_ProvidersMerged_Ok(t *testing.T) {
+	var cfg contextConfig
+	err := envconf.ParseContext(context.Background(), &cfg,
+		option.WithExternal(jsonconf.Json(`{"name": "file", "db": {"host": "file-db", "port": 1}}`)),
+		option.WithProvider(jsonProvider("first", `{"db": {"host": "first-db"}}`)),
+		option.WithProvider(jsonProvider("second", `{"name": "second"}`)),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Name != "second" || cfg.DB.Host != "first-db" || cfg.DB.Port != 1 {
+		t.Fatalf("unexpected result: %+v", cfg)
+	}
+}
+
+func TestParseContext_ProviderPosition_Ok(t *testing.T) {
+	var cfg contextConfig
+	ec := envconf.New()
+	err := ec.ParseContext(context.Background(), &cfg,
+		option.WithExternal(jsonconf.Json(`{"name": "file", "db": {"host": "file-db"}}`)),
+		option.WithProvider(funcProvider{name: "remote", load: func(context.Context) (external.External, error) {
+			return external.WithPath(jsonconf.Json(`{"db": {"host": "remote-db"}}`), "remote.json"), nil
+		}}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fr, _ := ec.Report().Field("DB.Host"); fr.Position.String() != "remote.json:1:17" {
+		t.Fatalf("unexpected position: %s", fr.Position)
+	}
+	if fr, _ := ec.Report().Field("Name"); fr.Position.String() != "1:10" {
+		t.Fatalf("unexpected position: %s", fr.Position)
+	}
+}
+
+func TestParseContext_LoadConcurrency_Ok(t *testing.T) {
+	const limit = 2
+	var running, max int32
+	var opts []option.ClientOption
+	for i := 0; i < 6; i++ {
+		opts = append(opts, option.WithProvider(funcProvider{name: "p", load: func(context.Context) (external.External, error) {
+			n := atomic.AddInt32(&running, 1)
+			defer atomic.AddInt32(&running, -1)
+			for {
+				m := atomic.LoadInt32(&max)
+				if n <= m || atomic.CompareAndSwapInt32(&max, m, n) {
+					break
+				}
+			}
+			time.Sleep(10 * time.Millisecond)
+			return jsonconf.Json(`{}`), nil
+		}}))
+	}
+	var cfg contextConfig
+	if err := envconf.Parse(&cfg, append(opts, option.WithLoadConcurrency(limit))...); err != nil {
+		t.Fatal(err)
+	}
+	if max > limit {
+		t.Fatalf("expected at most %d providers at once but got %d", limit, max)
+	}
+}
+
+func TestParseContext_ProvidersConcurrent_Ok(t *testing.T) {
+	// each provider waits for the other one, so sequential loading would stall
+	var wg sync.WaitGroup
+	wg.Add(2)
+	provider := func(name string) option.ClientOption {
+		return option.WithProvider(funcProvider{name: name, load: func(ctx context.Context) (external.External, error) {
+			wg.Done()
+			wg.Wait()
+			return jsonconf.Json(`{}`), nil
+		}})
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	var cfg contextConfig
+	if err := envconf.ParseContext(ctx, &cfg, provider("a"), provider("b")); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestParseContext_Timeout_Err(t *testing.T) {
+	stalled := make(chan struct{})
+	defer close(stalled)
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	var cfg contextConfig
+	err := envconf.ParseContext(ctx, &cfg,
+		option.WithProvider(jsonProvider("fast", `{"name": "fast"}`)),
+		// provider ignores ctx
+		option.WithProvider(funcProvider{name: "consul", load: func(context.Context) (external.External, error) {
+			<-stalled
+			return nil, errors.New("stalled")
+		}}),
+	)
+	var le *envconf.LoadError
+	if !errors.As(err, &le) || le.Source != "consul" {
+		t.Fatalf("expected LoadError of consul but got %v", err)
+	}
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected context.DeadlineExceeded but got %v", err)
+	}
+	if !strings.Contains(err.Error(), `provider "consul" stalled`) || strings.Contains(err.Error(), "fast") {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if cfg.Name != "" {
+		t.Fatalf("data is changed: %+v", cfg)
+	}
+}
+
+func TestParseContext_ProviderError_Err(t *testing.T) {
+	errLoad := errors.New("connection refused")
+	var cfg contextConfig
+	err := envconf.Parse(&cfg, option.WithProvider(funcProvider{name: "http", load: func(context.Context) (external.External, error) {
+		return nil, errLoad
+	}}))
+	var le *envconf.LoadError
+	if !errors.As(err, &le) || le.Source != "http" || !errors.Is(err, errLoad) {
+		t.Fatalf("expected LoadError of http but got %v", err)
+	}
+	if err.Error() != `provider "http": connection refused` {
+		t.Fatalf("unexpected error: %s", err)
+	}
+}
+
+func TestParseContext_Canceled_Err(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	var cfg contextConfig
+	if err := envconf.ParseContext(ctx, &cfg); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled but got %v", err)
+	}
+}
@@ -0,0 +1,54 @@
+package envconf_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/antonmashko/envconf"
+	"github.com/antonmashko/envconf/option"
+)
+
+func TestEnvCheck_UnknownPrefixedEnv_Err(t *testing.T) {
+	os.Setenv("BILLING_DB_HOST", "localhost")
+	os.Setenv("BILLING_DB_HSOT", "localhost")
+	defer os.Unsetenv("BILLING_DB_HSOT")
+	data := struct {
+		DB struct {
+			Host string `env:"*"`
+			Port int    `env:"*" default:"5432"`
+		} `envconf:"billing_db"`
+	}{}
+	err := envconf.Parse(&data, option.WithStrictEnv("BILLING_"))
+	if err == nil {
+		t.Fatal("expected error but got nil")
+	}
+	const expected = `unknown keys in Environment source: BILLING_DB_HSOT (did you mean "BILLING_DB_HOST"?)`
+	if err.Error() != expected {
+		t.Fatalf("unexpected error.\nexpected=%s\nactual=  %s", expected, err)
+	}
+}
+
+func TestEnvCheck_Hook_Ok(t *testing.T) {
+	os.Setenv("ENVCHECK_FIELD", "1")
+	os.Setenv("ENVCHECK_OTHER", "2")
+	defer os.Unsetenv("ENVCHECK_OTHER")
+	data := struct {
+		Field int `env:"ENVCHECK_FIELD"`
+	}{}
+	var keys []option.UnknownKeyArg
+	err := envconf.Parse(&data,
+		option.WithEnvCheck("ENVCHECK_"),
+		option.WithUnknownKeyHook(func(arg option.UnknownKeyArg) {
+			keys = append(keys, arg)
+		}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if data.Field != 1 {
+		t.Fatalf("unexpected result: %#v", data)
+	}
+	if len(keys) != 1 || keys[0].Key != "ENVCHECK_OTHER" || keys[0].Source != option.EnvVariable {
+		t.Fatalf("unexpected unknown keys: %#v", keys)
+	}
+}
@@ -0,0 +1,99 @@
+package envconf_test
+
+import (
+	"errors"
+	"os"
+	"reflect"
+	"strconv"
+	"testing"
+
+	"github.com/antonmashko/envconf"
+	jsonconf "github.com/antonmashko/envconf/external/json"
+	"github.com/antonmashko/envconf/option"
+)
+
+func TestErrors_RequiredError_Ok(t *testing.T) {
+	data := struct {
+		Inner struct {
+			Field string `env:"TEST_ERRORS_REQUIRED" required:"true"`
+		}
+	}{}
+	err := envconf.Parse(&data)
+	var re *envconf.RequiredError
+	if !errors.As(err, &re) {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if re.FieldName != "Inner.Field" {
+		t.Fatalf("unexpected field name: %s", re.FieldName)
+	}
+	if !errors.Is(err, envconf.ErrConfigurationNotFound) {
+		t.Fatalf("expected ErrConfigurationNotFound: %v", err)
+	}
+}
+
+func TestErrors_ConversionError_Ok(t *testing.T) {
+	os.Setenv("TEST_ERRORS_CONVERSION", "abc")
+	data := struct {
+		Field int `env:"TEST_ERRORS_CONVERSION"`
+	}{}
+	err := envconf.Parse(&data)
+	var ce *envconf.ConversionError
+	if !errors.As(err, &ce) {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if ce.FieldName != "Field" || ce.Value != "abc" ||
+		ce.Type != reflect.TypeOf(0) || ce.Source != option.EnvVariable {
+		t.Fatalf("unexpected error: %#v", ce)
+	}
+	if !errors.Is(err, strconv.ErrSyntax) {
+		t.Fatalf("expected strconv.ErrSyntax: %v", err)
+	}
+}
+
+func TestErrors_ConversionErrorCollection_Ok(t *testing.T) {
+	os.Setenv("TEST_ERRORS_CONVERSION_MAP", "a:1,b:x")
+	data := struct {
+		Field map[string]int `env:"TEST_ERRORS_CONVERSION_MAP"`
+	}{}
+	err := envconf.Parse(&data)
+	var ce *envconf.ConversionError
+	if !errors.As(err, &ce) {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if ce.FieldName != "Field.b" || ce.Value != "x" {
+		t.Fatalf("unexpected error: %#v", ce)
+	}
+}
+
+func TestErrors_ExternalMappingError_Ok(t *testing.T) {
+	data := struct {
+		Inner struct {
+			Field int `json:"field"`
+		} `json:"inner"`
+	}{}
+	err := envconf.Parse(&data, option.WithExternal(jsonconf.Json(`{"inner": {"field": [1]}}`)))
+	var me *envconf.ExternalMappingError
+	if !errors.As(err, &me) {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(me.Path, []string{"inner", "field"}) {
+		t.Fatalf("unexpected path: %v", me.Path)
+	}
+}
+
+func TestErrors_ValidationError_Ok(t *testing.T) {
+	data := struct {
+		Field int `json:"field"`
+	}{}
+	err := envconf.Parse(&data,
+		option.WithExternal(jsonconf.Json(`{"field": "abc"}`)),
+		option.WithSchemaValidation(nil),
+	)
+	var ve *envconf.ValidationError
+	if !errors.As(err, &ve) {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(ve.Violations) != 1 || ve.Violations[0].Pointer != "/field" {
+		t.Fatalf("unexpected violations: %v", ve.Violations)
+	}
+}
@@ -0,0 +1,114 @@
+package envconf_test
+
+import (
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/antonmashko/envconf"
+	jsonconf "github.com/antonmashko/envconf/external/json"
+	"github.com/antonmashko/envconf/option"
+)
+
+func TestNames_DuplicateEnv_Err(t *testing.T) {
+	data := struct {
+		Inner struct {
+			Field string `env:"*"`
+		}
+		Field string `env:"INNER_FIELD"`
+	}{}
+	err := envconf.Parse(&data)
+	var e *envconf.Error
+	if !errors.As(err, &e) {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	const expected = `Field: env name "INNER_FIELD" is already used by Inner.Field`
+	if err.Error() != expected {
+		t.Fatalf("unexpected error.\nexpected=%s\nactual=  %s", expected, err)
+	}
+}
+
+func TestNames_SharedEnv_Ok(t *testing.T) {
+	os.Setenv("TEST_NAMES_SHARED_ENV", "value")
+	data := struct {
+		Inner struct {
+			Field string `env:"TEST_NAMES_SHARED_ENV"`
+		}
+		Field string `env:"TEST_NAMES_SHARED_ENV"`
+	}{}
+	if err := envconf.Parse(&data, option.WithSharedEnv()); err != nil {
+		t.Fatal(err)
+	}
+	if data.Field != "value" || data.Inner.Field != "value" {
+		t.Fatalf("unexpected result: %+v", data)
+	}
+}
+
+func TestNames_DuplicateFlag_Err(t *testing.T) {
+	data := struct {
+		Names struct {
+			DupFlag string `flag:"*"`
+		}
+		Field string `flag:"names-dupflag"`
+	}{}
+	err := envconf.Parse(&data)
+	const expected = `Field: flag name "names-dupflag" is already used by Names.DupFlag`
+	if err == nil || err.Error() != expected {
+		t.Fatalf("unexpected error.\nexpected=%s\nactual=  %v", expected, err)
+	}
+}
+
+func TestNames_FlagDefinedOutside_Err(t *testing.T) {
+	data := struct {
+		Field string `flag:"names-outside"`
+	}{}
+	if err := envconf.Parse(&data); err != nil {
+		t.Fatal(err)
+	}
+	if err := envconf.Parse(&data); err == nil {
+		t.Fatal("expected error but got nil")
+	}
+}
+
+func TestNames_DuplicateExternalKey_Err(t *testing.T) {
+	data := struct {
+		Inner struct {
+			Host  string
+			Host2 string `json:"Host"`
+		} `json:"inner"`
+	}{}
+	err := envconf.Parse(&data, option.WithExternal(jsonconf.Json(`{"inner":{}}`)))
+	const expected = `Inner.Host2: external key "Host" is already used by Inner.Host`
+	if err == nil || err.Error() != expected {
+		t.Fatalf("unexpected error.\nexpected=%s\nactual=  %v", expected, err)
+	}
+}
+
+type namesNode struct {
+	Name     string      `json:"name"`
+	Children []namesNode `json:"children"`
+}
+
+func TestNames_ExternalKeysRecursiveType_Ok(t *testing.T) {
+	var data namesNode
+	err := envconf.Parse(&data, option.WithExternal(jsonconf.Json(`{"name":"a","children":[{"name":"b","children":[{"name":"c"}]}]}`)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if data.Name != "a" || len(data.Children) != 1 || len(data.Children[0].Children) != 1 ||
+		data.Children[0].Children[0].Name != "c" {
+		t.Fatalf("unexpected result: %+v", data)
+	}
+}
+
+func TestNames_InvalidDefaultOfUnusedSource_Err(t *testing.T) {
+	os.Setenv("TEST_NAMES_SLICE", "1,2")
+	data := struct {
+		Field []int `env:"TEST_NAMES_SLICE" default:"1,a"`
+	}{}
+	err := envconf.Parse(&data)
+	const expected = `Field: invalid default value type=[]int source=Default. strconv.ParseInt: parsing "a": invalid syntax`
+	if err == nil || err.Error() != expected {
+		t.Fatalf("unexpected error.\nexpected=%s\nactual=  %v", expected, err)
+	}
+}
@@ -0,0 +1,100 @@
+package envconf_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/antonmashko/envconf"
+	"github.com/antonmashko/envconf/external"
+	jsonconf "github.com/antonmashko/envconf/external/json"
+	"github.com/antonmashko/envconf/option"
+)
+
+const positionJSON = `{
+	"db": {
+		"host": "db",
+		"ports": [5432, 5433]
+	},
+	"nmae": "typo"
+}`
+
+type positionConfig struct {
+	DB struct {
+		Host  string `json:"host"`
+		Ports []int  `json:"ports"`
+	} `json:"db"`
+	Name string `json:"name"`
+}
+
+func TestPosition_FieldDefined_Ok(t *testing.T) {
+	p := &secretPrinter{}
+	var data positionConfig
+	ec := envconf.New()
+	err := ec.Parse(&data,
+		option.WithExternal(external.WithPath(jsonconf.Json(positionJSON), "config/prod.json")),
+		option.WithLog(p),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{
+		`field="DB.Host" value="db" type="string" source="External" position="config/prod.json:3:11"`,
+		`field="DB.Ports.0" value="5432" type="int" source="External" position="config/prod.json:4:13"`,
+		`field="DB.Ports.1" value="5433" type="int" source="External" position="config/prod.json:4:19"`,
+		`field="DB.Ports" value="[5432 5433]" type="[]int" source="External" position="config/prod.json:4:12"`,
+	}
+	for _, line := range expected {
+		if !strings.Contains(strings.Join(p.messages, "\n"), line) {
+			t.Fatalf("line %q not found in log:\n%s", line, strings.Join(p.messages, "\n"))
+		}
+	}
+	fr, _ := ec.Report().Field("DB.Host")
+	if fr.Position.String() != "config/prod.json:3:11" {
+		t.Fatalf("unexpected report position: %s", fr.Position)
+	}
+}
+
+func TestPosition_NotExternalSource_Ok(t *testing.T) {
+	data := struct {
+		Field string `default:"value"`
+	}{}
+	ec := envconf.New()
+	if err := ec.Parse(&data, option.WithExternal(jsonconf.Json(`{}`))); err != nil {
+		t.Fatal(err)
+	}
+	if fr, _ := ec.Report().Field("Field"); fr.Position.IsValid() {
+		t.Fatalf("unexpected position: %s", fr.Position)
+	}
+}
+
+func TestPosition_MappingError_Err(t *testing.T) {
+	var data positionConfig
+	err := envconf.Parse(&data,
+		option.WithExternal(external.WithPath(jsonconf.Json(`{"db": {"host": ["db"]}}`), "config/prod.json")))
+	var me *envconf.ExternalMappingError
+	if !errors.As(err, &me) {
+		t.Fatalf("expected ExternalMappingError but got %v", err)
+	}
+	const expected = "db.host: unable to cast []interface{} into string (config/prod.json:1:17)"
+	if err.Error() != expected {
+		t.Fatalf("expected %q but got %q", expected, err)
+	}
+}
+
+func TestPosition_UnknownKey_Ok(t *testing.T) {
+	var args []option.UnknownKeyArg
+	var data positionConfig
+	err := envconf.Parse(&data,
+		option.WithExternal(jsonconf.Json(positionJSON)),
+		option.WithUnknownKeyHook(func(arg option.UnknownKeyArg) {
+			args = append(args, arg)
+		}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(args) != 1 || args[0].Key != "nmae" || args[0].Position.String() != "6:10" {
+		t.Fatalf("unexpected unknown keys: %+v", args)
+	}
+}
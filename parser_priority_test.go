@@ -0,0 +1,80 @@
+package envconf_test
+
+import (
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/antonmashko/envconf"
+	jsonconf "github.com/antonmashko/envconf/external/json"
+	"github.com/antonmashko/envconf/option"
+)
+
+func TestPriorityTag_Field_Ok(t *testing.T) {
+	os.Setenv("TEST_PRIORITY_TAG_FIELD1", "from-env")
+	os.Setenv("TEST_PRIORITY_TAG_FIELD2", "from-env")
+	data := struct {
+		Field1 string `env:"TEST_PRIORITY_TAG_FIELD1" json:"field1" priority:"external,env,flag,default"`
+		Field2 string `env:"TEST_PRIORITY_TAG_FIELD2" json:"field2"`
+	}{}
+	ec := envconf.New()
+	err := ec.Parse(&data, option.WithExternal(jsonconf.Json(`{"field1": "from-json", "field2": "from-json"}`)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if data.Field1 != "from-json" || data.Field2 != "from-env" {
+		t.Fatalf("unexpected result: %#v", data)
+	}
+	fr, _ := ec.Report().Field("Field1")
+	expected := []option.ConfigSource{option.ExternalSource, option.EnvVariable, option.FlagVariable, option.DefaultValue}
+	if !reflect.DeepEqual(fr.PriorityOrder, expected) {
+		t.Fatalf("unexpected priority order: %v", fr.PriorityOrder)
+	}
+	if !strings.Contains(ec.Report().String(),
+		"Field1: from-json (External 1:12) shadowed from-env (Environment) [External > Environment > Flag > Default]") {
+		t.Fatalf("unexpected report: %s", ec.Report())
+	}
+}
+
+func TestPriorityTag_InheritedFromStruct_Ok(t *testing.T) {
+	os.Setenv("TEST_PRIORITY_TAG_INNER_FIELD1", "from-env")
+	os.Setenv("TEST_PRIORITY_TAG_INNER_FIELD2", "from-env")
+	data := struct {
+		Inner struct {
+			Field1 string `env:"TEST_PRIORITY_TAG_INNER_FIELD1" default:"from-default"`
+			Field2 string `env:"TEST_PRIORITY_TAG_INNER_FIELD2" default:"from-default" priority:"env"`
+		} `priority:"default,env"`
+	}{}
+	if err := envconf.Parse(&data); err != nil {
+		t.Fatal(err)
+	}
+	if data.Inner.Field1 != "from-default" || data.Inner.Field2 != "from-env" {
+		t.Fatalf("unexpected result: %#v", data)
+	}
+}
+
+func TestPriorityTag_WithSources_Ok(t *testing.T) {
+	data := struct {
+		Field string `default:"value" priority:"external,env,default" sources:"env,default"`
+	}{}
+	ec := envconf.New()
+	if err := ec.Parse(&data); err != nil {
+		t.Fatal(err)
+	}
+	fr, _ := ec.Report().Field("Field")
+	if !reflect.DeepEqual(fr.PriorityOrder, []option.ConfigSource{option.EnvVariable, option.DefaultValue}) {
+		t.Fatalf("unexpected priority order: %v", fr.PriorityOrder)
+	}
+}
+
+func TestPriorityTag_Invalid_Err(t *testing.T) {
+	data := struct {
+		Field string `priority:"env,file"`
+	}{}
+	err := envconf.Parse(&data)
+	const expected = `Field: invalid priority tag unknown config source "file"`
+	if err == nil || err.Error() != expected {
+		t.Fatalf("unexpected error.\nexpected=%s\nactual=  %v", expected, err)
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"testing"
 
 	"github.com/antonmashko/envconf"
+	jsonconf "github.com/antonmashko/envconf/external/json"
+	"github.com/antonmashko/envconf/option"
 )
 
 func TestStringPointer_InitFromDefault_Ok(t *testing.T) {
@@ -114,3 +116,23 @@ func TestStructPoint_FewInnerOneWithValue_Ok(t *testing.T) {
 		t.Fatalf("incorrect value Inner3. expected=not_nil actual=%#v", data.Inner3)
 	}
 }
+
+func TestPointer_FromExternal_Ok(t *testing.T) {
+	data := struct {
+		Field *int `json:"field"`
+		Inner *struct {
+			Field1 string `json:"field1"`
+			Field2 string `default:"test"`
+		} `json:"inner"`
+	}{}
+	ext := jsonconf.Json(`{"field": 1, "inner": {"field1": "ext"}}`)
+	if err := envconf.Parse(&data, option.WithExternal(ext)); err != nil {
+		t.Fatal(err)
+	}
+	if data.Field == nil || *data.Field != 1 {
+		t.Fatalf("incorrect value. expected=1 actual=%v", data.Field)
+	}
+	if data.Inner == nil || data.Inner.Field1 != "ext" || data.Inner.Field2 != "test" {
+		t.Fatalf("incorrect value: %v", data.Inner)
+	}
+}
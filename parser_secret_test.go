@@ -0,0 +1,145 @@
+package envconf_test
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/antonmashko/envconf"
+	jsonconf "github.com/antonmashko/envconf/external/json"
+	"github.com/antonmashko/envconf/option"
+)
+
+type secretPrinter struct {
+	messages []string
+}
+
+func (p *secretPrinter) Print(args ...interface{}) {
+	p.messages = append(p.messages, fmt.Sprint(args...))
+}
+
+func TestSecret_TagRedactsError_Ok(t *testing.T) {
+	os.Setenv("TEST_SECRET_TAG", "hunter2")
+	data := struct {
+		Field int `env:"TEST_SECRET_TAG" secret:"true"`
+	}{}
+	p := &secretPrinter{}
+	err := envconf.Parse(&data, option.WithLog(p))
+	if err == nil {
+		t.Fatal("expected error but got nil")
+	}
+	if strings.Contains(err.Error(), "hunter2") || !strings.Contains(err.Error(), option.SecretMask) {
+		t.Fatalf("raw value in error: %v", err)
+	}
+	if !errors.Is(err, strconv.ErrSyntax) {
+		t.Fatalf("expected strconv.ErrSyntax: %v", err)
+	}
+	var ce *envconf.ConversionError
+	if !errors.As(err, &ce) || ce.Value != option.SecretMask {
+		t.Fatalf("unexpected conversion error: %#v", ce)
+	}
+	var ne *strconv.NumError
+	if errors.As(err, &ne) {
+		t.Fatalf("raw value is reachable: %#v", ne)
+	}
+	for _, msg := range p.messages {
+		if strings.Contains(msg, "hunter2") {
+			t.Fatalf("raw value in log: %s", msg)
+		}
+	}
+}
+
+func TestSecret_LoggerRegexRedactsError_Ok(t *testing.T) {
+	os.Setenv("TEST_SECRET_REGEX", "1,hunter2")
+	data := struct {
+		DB struct {
+			Password []int `env:"TEST_SECRET_REGEX"`
+		}
+	}{}
+	err := envconf.Parse(&data, option.WithLog(nil))
+	if err == nil {
+		t.Fatal("expected error but got nil")
+	}
+	if strings.Contains(err.Error(), "hunter2") {
+		t.Fatalf("raw value in error: %v", err)
+	}
+}
+
+func TestSecret_NotSecret_Ok(t *testing.T) {
+	os.Setenv("TEST_SECRET_NOT_SECRET", "abc")
+	data := struct {
+		Field int `env:"TEST_SECRET_NOT_SECRET"`
+	}{}
+	err := envconf.Parse(&data, option.WithLog(nil))
+	if err == nil || !strings.Contains(err.Error(), "abc") {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestSecret_ParentTagRedactsHookValue_Ok(t *testing.T) {
+	os.Setenv("TEST_SECRET_PARENT", "value")
+	data := struct {
+		Credentials struct {
+			DSN string `env:"TEST_SECRET_PARENT"`
+		} `secret:"true"`
+	}{}
+	p := &secretPrinter{}
+	l := option.WithLog(p)
+	l.HideSecrets = false
+	if err := envconf.Parse(&data, l); err != nil {
+		t.Fatal(err)
+	}
+	if data.Credentials.DSN != "value" {
+		t.Fatalf("unexpected value: %s", data.Credentials.DSN)
+	}
+	const expected = `field="Credentials.DSN" value="******" type="string" source="Environment"`
+	if len(p.messages) != 1 || p.messages[0] != expected {
+		t.Fatalf("unexpected log: %v", p.messages)
+	}
+}
+
+func TestSecret_ExternalErrorsWithoutValue_Ok(t *testing.T) {
+	data := struct {
+		Password string `json:"password" secret:"true" pattern:"^[a-z]+$"`
+		Pin      uint8  `json:"pin" secret:"true"`
+		Code     int    `json:"code,string" secret:"true"`
+	}{}
+	for _, c := range []struct {
+		json  string
+		value string
+		opts  []option.ClientOption
+	}{
+		{`{"password":"Hunter2!"}`, "Hunter2!", []option.ClientOption{option.WithSchemaValidation(nil)}},
+		{`{"pin":4242}`, "4242", nil},
+		{`{"code":"Hunter2!"}`, "Hunter2!", nil},
+	} {
+		err := envconf.Parse(&data, append(c.opts, option.WithExternal(jsonconf.Json(c.json)))...)
+		if err == nil || strings.Contains(err.Error(), c.value) {
+			t.Fatalf("%s: unexpected error: %v", c.json, err)
+		}
+	}
+}
+
+func TestSecret_InvalidTag_Err(t *testing.T) {
+	secret := struct {
+		Inner struct {
+			Field int
+		} `secret:"yes"`
+	}{}
+	err := envconf.Parse(&secret)
+	const expectedSecret = `Inner: invalid secret tag strconv.ParseBool: parsing "yes": invalid syntax`
+	if err == nil || err.Error() != expectedSecret {
+		t.Fatalf("unexpected error.\nexpected=%s\nactual=  %v", expectedSecret, err)
+	}
+	reload := struct {
+		Field int `reload:"on"`
+	}{}
+	err = envconf.Parse(&reload)
+	const expectedReload = `Field: invalid reload tag strconv.ParseBool: parsing "on": invalid syntax`
+	if err == nil || err.Error() != expectedReload {
+		t.Fatalf("unexpected error.\nexpected=%s\nactual=  %v", expectedReload, err)
+	}
+}
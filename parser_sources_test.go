@@ -0,0 +1,94 @@
+package envconf_test
+
+import (
+	"errors"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/antonmashko/envconf"
+	jsonconf "github.com/antonmashko/envconf/external/json"
+	"github.com/antonmashko/envconf/option"
+)
+
+func TestSources_AllowedSource_Ok(t *testing.T) {
+	os.Setenv("TEST_SOURCES_ALLOWED", "env")
+	data := struct {
+		Field string `env:"TEST_SOURCES_ALLOWED" sources:"env,external"`
+	}{}
+	if err := envconf.Parse(&data); err != nil {
+		t.Fatal(err)
+	}
+	if data.Field != "env" {
+		t.Fatalf("unexpected value: %s", data.Field)
+	}
+}
+
+func TestSources_DisallowedSourceProvidedValue_Err(t *testing.T) {
+	data := struct {
+		Field string `json:"field" default:"value" sources:"env,default"`
+	}{}
+	err := envconf.Parse(&data, option.WithExternal(jsonconf.Json(`{"field": "external"}`)))
+	var se *envconf.SourceError
+	if !errors.As(err, &se) {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if se.FieldName != "Field" || se.Source != option.ExternalSource ||
+		!reflect.DeepEqual(se.Allowed, []option.ConfigSource{option.EnvVariable, option.DefaultValue}) {
+		t.Fatalf("unexpected error: %#v", se)
+	}
+	const expected = "Field: source is not allowed source=External allowed=[Environment Default]"
+	if err.Error() != expected {
+		t.Fatalf("unexpected error.\nexpected=%s\nactual=  %s", expected, err)
+	}
+}
+
+func TestSources_DisallowedSourceWithoutValue_Ok(t *testing.T) {
+	data := struct {
+		Field string `json:"field" default:"value" sources:"env,default"`
+	}{}
+	if err := envconf.Parse(&data, option.WithExternal(jsonconf.Json(`{}`))); err != nil {
+		t.Fatal(err)
+	}
+	if data.Field != "value" {
+		t.Fatalf("unexpected value: %s", data.Field)
+	}
+}
+
+func TestSources_InvalidTag_Err(t *testing.T) {
+	data := struct {
+		Field string `sources:"env,file"`
+	}{}
+	err := envconf.Parse(&data)
+	const expected = `Field: invalid sources tag unknown config source "file"`
+	if err == nil || err.Error() != expected {
+		t.Fatalf("unexpected error.\nexpected=%s\nactual=  %v", expected, err)
+	}
+}
+
+func TestSources_SecretFromFlag_Err(t *testing.T) {
+	os.Args = append(os.Args, "-test-sources-password=flag")
+	os.Setenv("TEST_SOURCES_SECRET", "env")
+	data := struct {
+		Password string `flag:"test-sources-password" env:"TEST_SOURCES_SECRET" secret:"true"`
+	}{}
+	err := envconf.Parse(&data, option.WithSecretSources(option.EnvVariable, option.ExternalSource))
+	var se *envconf.SourceError
+	if !errors.As(err, &se) || se.Source != option.FlagVariable {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestSources_SecretPolicy_Ok(t *testing.T) {
+	os.Setenv("TEST_SOURCES_SECRET_OK", "env")
+	data := struct {
+		Password string `flag:"test-sources-password-ok" env:"TEST_SOURCES_SECRET_OK" secret:"true"`
+	}{}
+	err := envconf.Parse(&data, option.WithSecretSources(option.EnvVariable, option.ExternalSource))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if data.Password != "env" {
+		t.Fatalf("unexpected value: %s", data.Password)
+	}
+}
@@ -0,0 +1,104 @@
+package envconf_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/antonmashko/envconf"
+	jsonconf "github.com/antonmashko/envconf/external/json"
+	"github.com/antonmashko/envconf/option"
+)
+
+func TestStrictPriority_ExternalNotInPriorityOrder_Ok(t *testing.T) {
+	const json = `{"field": "from-json", "inner": {"field": "from-json"}}`
+	type config struct {
+		Field string `json:"field"`
+		Inner struct {
+			Field string `json:"field"`
+		} `json:"inner"`
+	}
+	opts := []option.ClientOption{
+		option.WithExternal(jsonconf.Json(json)),
+		option.WithPriorityOrder(option.FlagVariable, option.EnvVariable, option.DefaultValue),
+	}
+
+	var data config
+	if err := envconf.Parse(&data, opts...); err != nil {
+		t.Fatal(err)
+	}
+	if data.Field != "from-json" || data.Inner.Field != "from-json" {
+		t.Fatalf("external values are expected without strict priority: %#v", data)
+	}
+
+	data = config{}
+	if err := envconf.Parse(&data, append(opts, option.WithStrictPriority())...); err != nil {
+		t.Fatal(err)
+	}
+	if data.Field != "" || data.Inner.Field != "" {
+		t.Fatalf("external values are applied with strict priority: %#v", data)
+	}
+}
+
+func TestStrictPriority_ExternalWins_Ok(t *testing.T) {
+	const json = `{"field": "from-json", "ptr": {"port": 8080}, "hosts": ["a", "b"], "any": {"k": 1}}`
+	data := struct {
+		Field string `json:"field" default:"from-default"`
+		Ptr   *struct {
+			Port int `json:"port"`
+		} `json:"ptr"`
+		Hosts []string    `json:"hosts"`
+		Any   interface{} `json:"any"`
+	}{}
+	err := envconf.Parse(&data, option.WithExternal(jsonconf.Json(json)), option.WithStrictPriority())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if data.Field != "from-json" || data.Ptr == nil || data.Ptr.Port != 8080 ||
+		len(data.Hosts) != 2 || data.Hosts[1] != "b" || data.Any == nil {
+		t.Fatalf("unexpected result: %#v", data)
+	}
+}
+
+func TestStrictPriority_ArrayFromEnv_Ok(t *testing.T) {
+	os.Setenv("TEST_STRICT_PRIORITY_ARRAY", "9")
+	const json = `{"field": [1, 2, 3]}`
+	type config struct {
+		Field [3]int `json:"field" env:"TEST_STRICT_PRIORITY_ARRAY"`
+	}
+	var data config
+	if err := envconf.Parse(&data, option.WithExternal(jsonconf.Json(json))); err != nil {
+		t.Fatal(err)
+	}
+	if data.Field != [3]int{9, 2, 3} {
+		t.Fatalf("unexpected result without strict priority: %v", data.Field)
+	}
+	data = config{}
+	err := envconf.Parse(&data, option.WithExternal(jsonconf.Json(json)), option.WithStrictPriority())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if data.Field != [3]int{9, 0, 0} {
+		t.Fatalf("unexpected result with strict priority: %v", data.Field)
+	}
+}
+
+type strictPriorityInner struct {
+	Field string `json:"field"`
+}
+
+func TestStrictPriority_InterfaceKeepsType_Ok(t *testing.T) {
+	inner := &strictPriorityInner{}
+	data := struct {
+		Inner interface{} `json:"inner"`
+	}{Inner: inner}
+	err := envconf.Parse(&data,
+		option.WithExternal(jsonconf.Json(`{"inner": {"field": "from-json"}}`)),
+		option.WithStrictPriority(),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if data.Inner != inner || inner.Field != "from-json" {
+		t.Fatalf("unexpected result: %#v", data.Inner)
+	}
+}
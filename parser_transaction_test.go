@@ -0,0 +1,115 @@
+package envconf_test
+
+import (
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/antonmashko/envconf"
+	jsonconf "github.com/antonmashko/envconf/external/json"
+	"github.com/antonmashko/envconf/option"
+)
+
+func TestTransaction_FailedParseKeepsData_Ok(t *testing.T) {
+	type inner struct {
+		Port int `json:"port" env:"TEST_TRANSACTION_FAILED_PORT"`
+	}
+	type config struct {
+		Name  string            `json:"name"`
+		Inner *inner            `json:"inner"`
+		Hosts []string          `json:"hosts"`
+		Tags  map[string]string `json:"tags"`
+		Rate  int               `env:"TEST_TRANSACTION_FAILED_RATE"`
+	}
+	in := &inner{Port: 80}
+	data := config{
+		Name:  "old",
+		Inner: in,
+		Hosts: []string{"a"},
+		Tags:  map[string]string{"k": "v"},
+	}
+	expected := config{
+		Name:  "old",
+		Inner: &inner{Port: 80},
+		Hosts: []string{"a"},
+		Tags:  map[string]string{"k": "v"},
+	}
+	const json = `{"name": "new", "inner": {"port": 8080}, "hosts": ["b", "c"], "tags": {"x": "y"}}`
+	os.Setenv("TEST_TRANSACTION_FAILED_RATE", "not-a-number")
+	defer os.Unsetenv("TEST_TRANSACTION_FAILED_RATE")
+
+	err := envconf.Parse(&data, option.WithExternal(jsonconf.Json(json)))
+	if err == nil {
+		t.Fatal("expected error but got nil")
+	}
+	if data.Inner != in {
+		t.Fatal("pointer is replaced")
+	}
+	if !reflect.DeepEqual(data, expected) {
+		t.Fatalf("data is changed by failed parsing: %#v", data)
+	}
+}
+
+func TestTransaction_CommitKeepsPointers_Ok(t *testing.T) {
+	type inner struct {
+		Port int `env:"TEST_TRANSACTION_COMMIT_PORT"`
+	}
+	type item struct {
+		Port int `default:"443"`
+	}
+	in := &inner{}
+	it := &item{}
+	data := struct {
+		Inner *inner
+		Any   interface{}
+	}{
+		Inner: in,
+		Any:   it,
+	}
+	os.Setenv("TEST_TRANSACTION_COMMIT_PORT", "8080")
+	defer os.Unsetenv("TEST_TRANSACTION_COMMIT_PORT")
+
+	if err := envconf.Parse(&data); err != nil {
+		t.Fatal(err)
+	}
+	if data.Inner != in || in.Port != 8080 {
+		t.Fatalf("unexpected result: %#v", data.Inner)
+	}
+	if data.Any != it || it.Port != 443 {
+		t.Fatalf("unexpected result: %#v", data.Any)
+	}
+}
+
+func TestTransaction_ReparseSameEnvConf_Ok(t *testing.T) {
+	data := struct {
+		Field string `env:"TEST_TRANSACTION_REPARSE_FIELD" flag:"test-transaction-reparse-field"`
+		Port  int    `env:"TEST_TRANSACTION_REPARSE_PORT"`
+	}{}
+	ec := envconf.New()
+	os.Setenv("TEST_TRANSACTION_REPARSE_FIELD", "first")
+	defer os.Unsetenv("TEST_TRANSACTION_REPARSE_FIELD")
+	if err := ec.Parse(&data); err != nil {
+		t.Fatal(err)
+	}
+	if data.Field != "first" {
+		t.Fatalf("unexpected result: %#v", data)
+	}
+
+	os.Setenv("TEST_TRANSACTION_REPARSE_FIELD", "second")
+	os.Setenv("TEST_TRANSACTION_REPARSE_PORT", "invalid")
+	defer os.Unsetenv("TEST_TRANSACTION_REPARSE_PORT")
+	if err := ec.Parse(&data); err == nil {
+		t.Fatal("expected error but got nil")
+	}
+	if data.Field != "first" {
+		t.Fatalf("data is changed by failed parsing: %#v", data)
+	}
+
+	os.Setenv("TEST_TRANSACTION_REPARSE_PORT", "8080")
+	if err := ec.Parse(&data); err != nil {
+		t.Fatal(err)
+	}
+	if data.Field != "second" || data.Port != 8080 {
+		t.Fatalf("unexpected result: %#v", data)
+	}
+}
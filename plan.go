@@ -0,0 +1,122 @@
+package envconf
+
+import (
+	"reflect"
+	"strconv"
+	"sync"
+
+	"github.com/antonmashko/envconf/option"
+)
+
+// fieldTags holds parsed tags of the struct field
+type fieldTags struct {
+	flag, env       string
+	hasFlag, hasEnv bool
+
+	defaultValue string
+	hasDefault   bool
+
+	required    bool
+	requiredErr error
+	description string
+	secret      bool
+	secretErr   error
+	reload      bool
+	reloadErr   error
+	// sname overrides struct name for generating configuration names
+	sname string
+
+	sources     []option.ConfigSource
+	hasSources  bool
+	sourcesErr  error
+	priority    []option.ConfigSource
+	hasPriority bool
+	priorityErr error
+
+	// result of default value check is cached for the type of the field
+	defaultOnce sync.Once
+	defaultType reflect.Type
+	defaultErr  error
+}
+
+// checkDefault checks that default value can be converted into rt
+func (t *fieldTags) checkDefault(rt reflect.Type) error {
+	t.defaultOnce.Do(func() {
+		t.defaultType = rt
+		t.defaultErr = checkDefault(rt, t.defaultValue)
+	})
+	if t.defaultType != rt {
+		return checkDefault(rt, t.defaultValue)
+	}
+	return t.defaultErr
+}
+
+func parseTags(tag reflect.StructTag) *fieldTags {
+	t := &fieldTags{}
+	if tag == "" {
+		return t
+	}
+	t.flag, t.hasFlag = tag.Lookup(tagFlag)
+	t.env, t.hasEnv = tag.Lookup(tagEnv)
+	t.defaultValue, t.hasDefault = tag.Lookup(tagDefault)
+	if req, ok := tag.Lookup(tagRequired); ok {
+		t.required, t.requiredErr = strconv.ParseBool(req)
+	}
+	t.description = tag.Get(tagDescription)
+	if secret, ok := tag.Lookup(tagSecret); ok {
+		t.secret, t.secretErr = strconv.ParseBool(secret)
+	}
+	if reload, ok := tag.Lookup(tagReload); ok {
+		t.reload, t.reloadErr = strconv.ParseBool(reload)
+	}
+	t.sname = tag.Get("envconf")
+	var str string
+	if str, t.hasSources = tag.Lookup(tagSources); t.hasSources {
+		t.sources, t.sourcesErr = option.ParseConfigSources(str)
+	}
+	if str, t.hasPriority = tag.Lookup(tagPriority); t.hasPriority {
+		t.priority, t.priorityErr = option.ParseConfigSources(str)
+	}
+	return t
+}
+
+// structPlan is compiled description of the struct type.
+// Plan is immutable and shared by all Parse calls
+type structPlan struct {
+	fields []reflect.StructField
+	tags   []*fieldTags
+	// size is a number of fields including fields of nested structs.
+	// Used for preallocating state of the resolution
+	size int
+}
+
+// plans caches *structPlan by reflect.Type
+var plans sync.Map
+
+func planOf(rt reflect.Type) *structPlan {
+	if p, ok := plans.Load(rt); ok {
+		return p.(*structPlan)
+	}
+	p := &structPlan{
+		fields: make([]reflect.StructField, rt.NumField()),
+		tags:   make([]*fieldTags, rt.NumField()),
+	}
+	for i := range p.fields {
+		p.fields[i] = rt.Field(i)
+		p.tags[i] = parseTags(p.fields[i].Tag)
+		p.size++
+		if ft := p.fields[i].Type; ft.Kind() == reflect.Struct {
+			p.size += planOf(ft).size
+		}
+	}
+	actual, _ := plans.LoadOrStore(rt, p)
+	return actual.(*structPlan)
+}
+
+// tagsOf returns parsed tags of the field
+func tagsOf(f field) *fieldTags {
+	if c, ok := f.(interface{ config() *configField }); ok {
+		return c.config().tag()
+	}
+	return parseTags(f.structField().Tag)
+}
@@ -1,6 +1,7 @@
 package envconf
 
 import (
+	"errors"
 	"reflect"
 	"strconv"
 	"strings"
@@ -72,3 +73,79 @@ func setFromString(rv reflect.Value, value string) (interface{}, error) {
 		return nil, ErrUnsupportedType
 	}
 }
+
+// checkDefault verifies that value can be converted into rt
+func checkDefault(rt reflect.Type, value string) error {
+	for rt.Kind() == reflect.Ptr {
+		rt = rt.Elem()
+	}
+	rv := reflect.New(rt).Elem()
+	if implF := asImpl(rv); implF != nil {
+		return implF([]byte(value))
+	}
+	switch rt.Kind() {
+	case reflect.Interface, reflect.Struct:
+		// defined by underlying value
+		return nil
+	case reflect.Slice, reflect.Array:
+		if rt.Kind() == reflect.Slice && rt.Elem().Kind() == reflect.Uint8 {
+			return nil
+		}
+		sl := strings.Split(value, ",")
+		if rt.Kind() == reflect.Array && len(sl) > rt.Len() {
+			return errors.New("elements in value more than len of array")
+		}
+		for i := range sl {
+			if err := checkDefault(rt.Elem(), sl[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+	case reflect.Map:
+		for _, item := range strings.Split(value, ",") {
+			key, val, _ := strings.Cut(item, ":")
+			if err := checkDefault(rt.Key(), key); err != nil {
+				return err
+			}
+			if err := checkDefault(rt.Elem(), val); err != nil {
+				return err
+			}
+		}
+		return nil
+	default:
+		_, err := setFromString(rv, value)
+		return err
+	}
+}
+
+// newShadow returns zero value of rv type that keeps non-nil pointers and concrete types of interfaces.
+// Decoding into the shadow goes into the same types as decoding into rv, but doesn't share memory with it
+func newShadow(rv reflect.Value) reflect.Value {
+	rt := rv.Type()
+	switch rv.Kind() {
+	case reflect.Struct:
+		result := reflect.New(rt).Elem()
+		for i := 0; i < rv.NumField(); i++ {
+			if f := result.Field(i); f.CanSet() {
+				f.Set(newShadow(rv.Field(i)))
+			}
+		}
+		return result
+	case reflect.Ptr:
+		if rv.IsNil() {
+			return reflect.Zero(rt)
+		}
+		result := reflect.New(rt.Elem())
+		result.Elem().Set(newShadow(rv.Elem()))
+		return result
+	case reflect.Interface:
+		if rv.IsNil() {
+			return reflect.Zero(rt)
+		}
+		result := reflect.New(rt).Elem()
+		result.Set(newShadow(rv.Elem()))
+		return result
+	default:
+		return reflect.Zero(rt)
+	}
+}
@@ -0,0 +1,161 @@
+package envconf
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/signal"
+	"reflect"
+
+	"github.com/antonmashko/envconf/option"
+)
+
+// errReloadByValue is returned by reload if data passed to Parse isn't a pointer, so there is no base for resolving it again
+var errReloadByValue = errors.New("configuration is parsed from the value, pass pointer to data for reloading")
+
+// reload resolves configuration again into the copy of data passed to the last Parse call.
+// External sources are read again, values of flags and environment variables are kept.
+// If strict is set, reload fails if fields that aren't marked as reloadable changed.
+// Subscribers of option.WithReloadSubscriber are notified with the result.
+// ctx is passed to providers of external data
+func (e *EnvConf) reload(ctx context.Context, strict bool) (reflect.Value, []option.FieldChange, error) {
+	e.reloadMu.Lock()
+	defer e.reloadMu.Unlock()
+	e.mu.Lock()
+	last, pub := e.last, e.value
+	e.mu.Unlock()
+	if last == nil {
+		return reflect.Value{}, nil, errors.New("configuration is not parsed")
+	}
+	if !last.base.IsValid() {
+		last.opts.OnReload(option.ReloadArg{Err: errReloadByValue})
+		return reflect.Value{}, nil, errReloadByValue
+	}
+	r := last.next()
+	r.ctx = ctx
+	rv, changes, err := r.resolveAgain(last, strict)
+	arg := option.ReloadArg{Changes: changes, Err: err}
+	if err == nil {
+		e.mu.Lock()
+		e.last = r
+		e.mu.Unlock()
+		arg.Config = rv.Interface()
+		if pub != nil {
+			pub.publish(arg.Config)
+		}
+	}
+	r.opts.OnReload(arg)
+	return rv, changes, err
+}
+
+// resolveAgain resolves configuration from the base of the previous resolution.
+// If strict is set, changes of the fields without reload tag reject the resolution
+func (r *resolver) resolveAgain(prev *resolver, strict bool) (reflect.Value, []option.FieldChange, error) {
+	rv := reflect.New(r.base.Type())
+	p := newStructType(rv.Elem(), newConfigField(nil, &reflect.StructField{}, r))
+	if err := r.parse(p, true); err != nil {
+		return reflect.Value{}, nil, err
+	}
+	changes, rejected := diffFields(prev.fields, r.fields)
+	if strict && len(rejected) > 0 {
+		return reflect.Value{}, changes, &ReloadError{FieldNames: rejected}
+	}
+	return rv, changes, nil
+}
+
+// diffFields compares resolution of the fields by their full names.
+// Returns changes and names of changed fields that aren't reloadable
+func diffFields(prev, next []*configField) ([]option.FieldChange, []string) {
+	old := make(map[string]*configField, len(prev))
+	for _, cf := range prev {
+		old[cf.fullName()] = cf
+	}
+	var changes []option.FieldChange
+	var rejected []string
+	add := func(o, n *configField) {
+		cf := n
+		if cf == nil {
+			cf = o
+		}
+		ch := option.FieldChange{
+			Name:       cf.name(),
+			FullName:   cf.fullName(),
+			Reloadable: cf.isReloadable(),
+			Secret:     cf.isSecret(),
+			OldSource:  option.NoConfigValue,
+			NewSource:  option.NoConfigValue,
+		}
+		if o != nil && o.isSet() {
+			ch.OldValue, ch.OldSource = o.value, o.source
+		}
+		if n != nil && n.isSet() {
+			ch.NewValue, ch.NewSource = n.value, n.source
+		}
+		if ch.OldSource == ch.NewSource && reflect.DeepEqual(ch.OldValue, ch.NewValue) {
+			return
+		}
+		if ch.Secret {
+			if ch.OldValue != nil {
+				ch.OldValue = option.SecretMask
+			}
+			if ch.NewValue != nil {
+				ch.NewValue = option.SecretMask
+			}
+		}
+		if !ch.Reloadable {
+			rejected = append(rejected, ch.FullName)
+		}
+		changes = append(changes, ch)
+	}
+	for _, cf := range next {
+		name := cf.fullName()
+		add(old[name], cf)
+		delete(old, name)
+	}
+	for _, cf := range prev {
+		if _, ok := old[cf.fullName()]; ok {
+			add(cf, nil)
+		}
+	}
+	return changes, rejected
+}
+
+// handleReloadSignals starts reloading configuration on signals of option.WithReloadSignal
+func (e *EnvConf) handleReloadSignals(opts *option.Options) {
+	sigs := opts.ReloadSignals()
+	if len(sigs) == 0 {
+		return
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.stopSignal != nil {
+		return
+	}
+	ch := make(chan os.Signal, 1)
+	done := make(chan struct{})
+	signal.Notify(ch, sigs...)
+	e.stopSignal = func() {
+		signal.Stop(ch)
+		close(done)
+	}
+	go func() {
+		for {
+			select {
+			case <-done:
+				return
+			case <-ch:
+				e.reload(context.Background(), true)
+			}
+		}
+	}()
+}
+
+// StopReload stops reloading configuration on signals of option.WithReloadSignal
+func (e *EnvConf) StopReload() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.stopSignal != nil {
+		e.stopSignal()
+		e.stopSignal = nil
+	}
+}
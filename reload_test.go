@@ -0,0 +1,131 @@
+//go:build unix
+
+package envconf_test
+
+import (
+	"errors"
+	"os"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/antonmashko/envconf"
+	jsonconf "github.com/antonmashko/envconf/external/json"
+	"github.com/antonmashko/envconf/option"
+)
+
+type fileExternal struct {
+	path string
+}
+
+func (f fileExternal) TagName() []string {
+	return []string{"json"}
+}
+
+func (f fileExternal) Unmarshal(v interface{}) error {
+	b, err := os.ReadFile(f.path)
+	if err != nil {
+		return err
+	}
+	return jsonconf.Json(b).Unmarshal(v)
+}
+
+func TestReloadSignal_Ok(t *testing.T) {
+	type config struct {
+		Addr string `json:"addr"`
+		HTTP struct {
+			Timeout int    `json:"timeout"`
+			Token   string `json:"token" secret:"true"`
+		} `json:"http" reload:"true"`
+	}
+	path := t.TempDir() + "/config.json"
+	writeFile(t, path, `{"addr": ":80", "http": {"timeout": 1, "token": "abc"}}`)
+
+	args := make(chan option.ReloadArg, 2)
+	ec := envconf.New()
+	var cfg config
+	err := ec.Parse(&cfg,
+		option.WithExternal(fileExternal{path: path}),
+		option.WithReloadSignal(),
+		option.WithReloadSubscriber(func(arg option.ReloadArg) { args <- arg }),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ec.StopReload()
+
+	writeFile(t, path, `{"addr": ":80", "http": {"timeout": 5, "token": "xyz"}}`)
+	if err = syscall.Kill(os.Getpid(), syscall.SIGHUP); err != nil {
+		t.Fatal(err)
+	}
+	var arg option.ReloadArg
+	select {
+	case arg = <-args:
+	case <-time.After(5 * time.Second):
+		t.Fatal("reload timeout")
+	}
+	if arg.Err != nil {
+		t.Fatal(arg.Err)
+	}
+	next := arg.Config.(*config)
+	if next.HTTP.Timeout != 5 || next.HTTP.Token != "xyz" || cfg.HTTP.Timeout != 1 {
+		t.Fatalf("unexpected result: %#v", next)
+	}
+	if len(arg.Changes) != 2 {
+		t.Fatalf("unexpected changes: %#v", arg.Changes)
+	}
+	ch := arg.Changes[0]
+	if ch.FullName != "HTTP.Timeout" || ch.OldValue != float64(1) || ch.NewValue != float64(5) ||
+		ch.OldSource != option.ExternalSource || ch.NewSource != option.ExternalSource || !ch.Reloadable {
+		t.Fatalf("unexpected change: %#v", ch)
+	}
+	ch = arg.Changes[1]
+	if ch.FullName != "HTTP.Token" || !ch.Secret || ch.OldValue != option.SecretMask || ch.NewValue != option.SecretMask {
+		t.Fatalf("unexpected change: %#v", ch)
+	}
+
+	// change of the field without reload tag is rejected
+	writeFile(t, path, `{"addr": ":8080", "http": {"timeout": 10}}`)
+	if err = syscall.Kill(os.Getpid(), syscall.SIGHUP); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case arg = <-args:
+	case <-time.After(5 * time.Second):
+		t.Fatal("reload timeout")
+	}
+	var re *envconf.ReloadError
+	if !errors.As(arg.Err, &re) || len(re.FieldNames) != 1 || re.FieldNames[0] != "Addr" {
+		t.Fatalf("unexpected error: %v", arg.Err)
+	}
+	if arg.Config != nil {
+		t.Fatalf("unexpected config: %#v", arg.Config)
+	}
+	if fr, ok := ec.Report().Field("HTTP.Timeout"); !ok || fr.Value != float64(5) {
+		t.Fatalf("report of the rejected reload: %#v", fr)
+	}
+}
+
+func TestReloadSignal_ParsedByValue_Err(t *testing.T) {
+	args := make(chan option.ReloadArg, 1)
+	ec := envconf.New()
+	err := ec.Parse(struct{}{},
+		option.WithReloadSignal(),
+		option.WithReloadSubscriber(func(arg option.ReloadArg) { args <- arg }),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ec.StopReload()
+	if err = syscall.Kill(os.Getpid(), syscall.SIGHUP); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case arg := <-args:
+		if arg.Err == nil || arg.Config != nil {
+			t.Fatalf("expected error but got: %#v", arg)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("reload timeout")
+	}
+}
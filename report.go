@@ -0,0 +1,122 @@
+package envconf
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/antonmashko/envconf/external"
+	"github.com/antonmashko/envconf/option"
+)
+
+// Candidate is a value provided by a single configuration source
+type Candidate struct {
+	Value  interface{}
+	Source option.ConfigSource
+	// Position of the value in the external source. Invalid for other sources
+	Position external.Position
+}
+
+// source returns name of the source with position of the value
+func (c Candidate) source() string {
+	if c.Position.IsValid() {
+		return fmt.Sprintf("%s %s", c.Source, c.Position)
+	}
+	return c.Source.String()
+}
+
+// FieldReport describes how a single field was resolved
+type FieldReport struct {
+	Name     string
+	FullName string
+	Type     reflect.Type
+	FlagName string
+	EnvName  string
+	// Secret is true if field holds sensitive value. Values of such fields are redacted
+	Secret bool
+	// PriorityOrder is an effective priority order of the field sources
+	PriorityOrder []option.ConfigSource
+
+	// Value and Source of the configuration source that won.
+	// Source is option.NoConfigValue if field left unset
+	Value  interface{}
+	Source option.ConfigSource
+	// Position of the value in the external source. Invalid for other sources
+	Position external.Position
+	// Shadowed contains values of lower priority sources that lost to Source
+	Shadowed []Candidate
+}
+
+// IsSet reports whether any configuration source defined the field
+func (r FieldReport) IsSet() bool {
+	return r.Source != option.NoConfigValue
+}
+
+// Report lists every configuration field with the source that defined it
+// and values that it shadowed
+type Report struct {
+	Fields []FieldReport
+}
+
+func newReport(fields []*configField) *Report {
+	r := &Report{
+		Fields: make([]FieldReport, 0, len(fields)),
+	}
+	for _, cf := range fields {
+		fr := FieldReport{
+			Name:          cf.name(),
+			FullName:      cf.fullName(),
+			Type:          cf.StructField.Type,
+			FlagName:      cf.configuration.flag.Name(),
+			EnvName:       cf.configuration.env.Name(),
+			Secret:        cf.isSecret(),
+			Source:        option.NoConfigValue,
+			PriorityOrder: cf.effectivePriorityOrder(),
+		}
+		if cf.isSet() {
+			fr.Value, fr.Source, fr.Position = cf.value, cf.source, cf.sourcePosition(cf.source)
+			won := false
+			for _, c := range cf.lookup() {
+				if !won && c.Source == cf.source {
+					won = true
+					continue
+				}
+				if fr.Secret {
+					c.Value = option.SecretMask
+				}
+				fr.Shadowed = append(fr.Shadowed, c)
+			}
+			if fr.Secret {
+				fr.Value = option.SecretMask
+			}
+		}
+		r.Fields = append(r.Fields, fr)
+	}
+	return r
+}
+
+// Field returns report of the field with specified full name
+func (r *Report) Field(fullName string) (FieldReport, bool) {
+	for _, f := range r.Fields {
+		if f.FullName == fullName {
+			return f, true
+		}
+	}
+	return FieldReport{}, false
+}
+
+func (r *Report) String() string {
+	var sb strings.Builder
+	for _, f := range r.Fields {
+		if !f.IsSet() {
+			fmt.Fprintf(&sb, "%s: not set [%s]\n", f.FullName, option.FormatPriorityOrder(f.PriorityOrder))
+			continue
+		}
+		fmt.Fprintf(&sb, "%s: %v (%s)", f.FullName, f.Value, Candidate{Source: f.Source, Position: f.Position}.source())
+		for _, c := range f.Shadowed {
+			fmt.Fprintf(&sb, " shadowed %v (%s)", c.Value, c.source())
+		}
+		fmt.Fprintf(&sb, " [%s]\n", option.FormatPriorityOrder(f.PriorityOrder))
+	}
+	return sb.String()
+}
@@ -0,0 +1,86 @@
+package envconf_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/antonmashko/envconf"
+	jsonconf "github.com/antonmashko/envconf/external/json"
+	"github.com/antonmashko/envconf/option"
+)
+
+func TestReport_WinnerAndShadowed_Ok(t *testing.T) {
+	os.Setenv("TEST_REPORT_FIELD1", "from-env")
+	data := struct {
+		Field1 string `env:"TEST_REPORT_FIELD1" default:"from-default"`
+		Inner  struct {
+			Field2 int `env:"TEST_REPORT_FIELD2"`
+		}
+	}{}
+	ec := envconf.New()
+	if ec.Report() != nil {
+		t.Fatal("report exists before Parse")
+	}
+	err := ec.Parse(&data, option.WithExternal(jsonconf.Json(`{"field1":"from-json"}`)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := ec.Report()
+	if r == nil {
+		t.Fatal("report is nil")
+	}
+	f1, ok := r.Field("Field1")
+	if !ok {
+		t.Fatal("Field1 not found in report")
+	}
+	if f1.EnvName != "TEST_REPORT_FIELD1" || f1.Value != "from-env" || f1.Source != option.EnvVariable {
+		t.Fatalf("unexpected winner: %#v", f1)
+	}
+	if len(f1.Shadowed) != 2 ||
+		f1.Shadowed[0].Source != option.ExternalSource || f1.Shadowed[0].Value != "from-json" ||
+		f1.Shadowed[1].Source != option.DefaultValue || f1.Shadowed[1].Value != "from-default" {
+		t.Fatalf("unexpected shadowed values: %#v", f1.Shadowed)
+	}
+	f2, ok := r.Field("Inner.Field2")
+	if !ok {
+		t.Fatal("Inner.Field2 not found in report")
+	}
+	if f2.IsSet() || len(f2.Shadowed) != 0 {
+		t.Fatalf("unexpected Inner.Field2 report: %#v", f2)
+	}
+}
+
+func TestReport_ParseErr_Nil(t *testing.T) {
+	data := struct {
+		Field1 int `default:"abc"`
+	}{}
+	ec := envconf.New()
+	if err := ec.Parse(&data); err == nil {
+		t.Fatal("expected error but got nil")
+	}
+	if ec.Report() != nil {
+		t.Fatal("report is not nil")
+	}
+}
+
+func TestReport_ParseErrKeepsLast_Ok(t *testing.T) {
+	ec := envconf.New()
+	ok := struct {
+		Field1 int `default:"1"`
+	}{}
+	if err := ec.Parse(&ok, option.WithPriorityOrder(option.DefaultValue)); err != nil {
+		t.Fatal(err)
+	}
+	failed := struct {
+		Field1 int `default:"abc"`
+	}{}
+	if err := ec.Parse(&failed); err == nil {
+		t.Fatal("expected error but got nil")
+	}
+	if fr, found := ec.Report().Field("Field1"); !found || fr.Source != option.DefaultValue {
+		t.Fatalf("unexpected report: %+v", fr)
+	}
+	if po := ec.PriorityOrder(); len(po) != 1 || po[0] != option.DefaultValue {
+		t.Fatalf("unexpected priority order: %v", po)
+	}
+}
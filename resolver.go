@@ -0,0 +1,486 @@
+package envconf
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/antonmashko/envconf/external"
+	"github.com/antonmashko/envconf/jsonschema"
+	"github.com/antonmashko/envconf/option"
+)
+
+// flagMu guards registration and parsing of the command line flags
+var flagMu sync.Mutex
+
+// resolver holds state of a single Parse call or reload
+type resolver struct {
+	ec   *EnvConf
+	opts *option.Options
+	// ctx is passed to providers of external data
+	ctx context.Context
+	// ext is external source merged with data of providers
+	ext     external.External
+	fields  []*configField
+	usedEnv map[string]struct{}
+	// names holds full names of the fields by configuration names of the kind (env or flag)
+	names map[string]map[string]string
+	// report is built from fields on the first request, see resolutionReport
+	report *Report
+	// order is a priority order of the options
+	order []option.ConfigSource
+	// shadow is a copy of data with values of external source. Used with option.WithStrictPriority
+	shadow reflect.Value
+	// resolving is set on reload, hooks of initialized fields are called only by Parse
+	resolving bool
+	// base is a copy of data before resolution. Reload resolves configuration from it
+	base reflect.Value
+	// env holds values of environment variables captured by Parse. Reload reuses them
+	env map[string]envValue
+}
+
+type envValue struct {
+	v  string
+	ok bool
+}
+
+func newResolver(e *EnvConf, opts *option.Options) *resolver {
+	return &resolver{
+		ec:   e,
+		opts: opts,
+		ctx:  context.Background(),
+	}
+}
+
+// next returns resolver for reloading configuration resolved by r
+func (r *resolver) next() *resolver {
+	n := newResolver(r.ec, r.opts)
+	n.base = r.base
+	n.env = make(map[string]envValue, len(r.env))
+	for k, v := range r.env {
+		n.env[k] = v
+	}
+	return n
+}
+
+func (r *resolver) flags() *flag.FlagSet {
+	return r.ec.flags()
+}
+
+func (r *resolver) registerFlag(s *flagSource, usage string) (*flagSource, error) {
+	return r.ec.registerFlag(s, usage)
+}
+
+// PriorityOrder returns priority order of the options
+func (r *resolver) PriorityOrder() []option.ConfigSource {
+	if r.order == nil {
+		r.order = r.opts.PriorityOrder()
+	}
+	return r.order
+}
+
+// lookupEnv retrieves environment variable and remembers its name as used if env check is enabled
+func (r *resolver) lookupEnv(name string) (string, bool) {
+	if _, _, ok := r.opts.EnvCheck(); ok {
+		if r.usedEnv == nil {
+			r.usedEnv = make(map[string]struct{})
+		}
+		r.usedEnv[name] = struct{}{}
+	}
+	if ev, ok := r.env[name]; ok {
+		return ev.v, ev.ok
+	}
+	if r.env == nil {
+		r.env = make(map[string]envValue)
+	}
+	v, ok := os.LookupEnv(name)
+	r.env[name] = envValue{v: v, ok: ok}
+	return v, ok
+}
+
+// registerName checks that configuration name of specified kind used only by one field.
+// Environment variables can be shared by fields if option.WithSharedEnv is set
+func (r *resolver) registerName(kind, name, fullName string) error {
+	if name == tagIgnored || kind == "env" && r.opts.SharedEnv() {
+		return nil
+	}
+	if r.names == nil {
+		r.names = make(map[string]map[string]string)
+	}
+	names := r.names[kind]
+	if names == nil {
+		names = make(map[string]string)
+		r.names[kind] = names
+	}
+	if other, ok := names[name]; ok {
+		return &Error{
+			FieldName: fullName,
+			Message:   fmt.Sprintf("%s name %q is already used by %s", kind, name, other),
+		}
+	}
+	names[name] = fullName
+	return nil
+}
+
+// reset clears initialized fields and their registered names. State is preallocated for size fields
+func (r *resolver) reset(size int) {
+	r.fields = make([]*configField, 0, size)
+	r.names = map[string]map[string]string{"env": make(map[string]string, size)}
+}
+
+// track remembers configuration field for the resolution report
+func (r *resolver) track(cf *configField) {
+	if cf.tracked {
+		return
+	}
+	cf.tracked = true
+	r.fields = append(r.fields, cf)
+}
+
+// resolutionReport returns report of the resolution. Report is built on the first call,
+// values of the fields and their sources don't change after resolution
+func (r *resolver) resolutionReport() *Report {
+	if r.report == nil {
+		r.report = newReport(r.fields)
+	}
+	return r.report
+}
+
+func (r *resolver) fieldInitialized(f field) {
+	cf := asConfigField(f)
+	if cf == nil {
+		return
+	}
+	r.track(cf)
+	if r.resolving || !r.opts.HasFieldInitialized() {
+		// hook is already called by Parse
+		return
+	}
+	r.opts.OnFieldInitialized(initializedArg(cf))
+}
+
+func initializedArg(cf *configField) option.FieldInitializedArg {
+	dv, _ := cf.configuration.defaultValue.Value()
+	secret := cf.isSecret()
+	if secret && dv != nil {
+		dv = option.SecretMask
+	}
+	return option.FieldInitializedArg{
+		Name:          cf.name(),
+		FullName:      cf.fullName(),
+		Type:          cf.StructField.Type,
+		Required:      cf.property.required,
+		Description:   cf.property.description,
+		FlagName:      cf.configuration.flag.Name(),
+		EnvName:       cf.configuration.env.Name(),
+		DefaultValue:  dv,
+		Secret:        secret,
+		PriorityOrder: cf.effectivePriorityOrder(),
+	}
+}
+
+func (r *resolver) fieldDefined(f field) {
+	cf := asConfigField(f)
+	if cf == nil || !cf.isSet() {
+		return
+	}
+	r.track(cf)
+	if !r.opts.HasFieldDefined() {
+		return
+	}
+	dv, _ := cf.configuration.defaultValue.Value()
+	v := cf.value
+	secret := cf.isSecret()
+	if secret {
+		v = option.SecretMask
+		if dv != nil {
+			dv = option.SecretMask
+		}
+	}
+	r.opts.OnFieldDefined(option.FieldDefinedArg{
+		Name:         cf.name(),
+		FullName:     cf.fullName(),
+		Type:         cf.StructField.Type,
+		Required:     cf.property.required,
+		Description:  cf.property.description,
+		FlagName:     cf.configuration.flag.Name(),
+		EnvName:      cf.configuration.env.Name(),
+		DefaultValue: dv,
+		Value:        v,
+		Source:       cf.source,
+		Secret:       secret,
+		Position:     cf.sourcePosition(cf.source),
+	})
+}
+
+func (r *resolver) fieldNotDefined(f field, err error) {
+	cf := asConfigField(f)
+	if cf == nil {
+		return
+	}
+	r.track(cf)
+	var pos external.Position
+	if c := cf.lookup(); len(c) != 0 {
+		pos = c[0].Position
+	}
+	// position is set only to the error of the field itself, errors of nested fields keep their positions
+	var e *Error
+	if errors.As(err, &e) && e.FieldName == cf.fullName() && !e.Position.IsValid() {
+		e.Position = pos
+	}
+	r.opts.OnFieldDefineErr(option.FieldDefineErrorArg{
+		Name:     cf.name(),
+		FullName: cf.fullName(),
+		Err:      err,
+		Secret:   cf.isSecret(),
+		Position: pos,
+	})
+}
+
+func (r *resolver) parse(p *structType, reload bool) error {
+	r.usedEnv, r.report = nil, nil
+	r.shadow = reflect.Value{}
+	r.resolving = reload
+	defer func() { r.resolving = false }()
+	rv := p.v
+	// fields, their names and values of environment variables are preallocated by the number of struct fields
+	size := planOf(rv.Type()).size
+	r.reset(size)
+	if r.env == nil {
+		r.env = make(map[string]envValue, size)
+	}
+	p, data, copied := r.target(rv, reload)
+	err := p.init()
+	if err != nil {
+		return err
+	}
+	if reload {
+		if cf, ok := r.opts.External().(option.ConfigFile); ok {
+			if err = cf.Reload(); err != nil {
+				return err
+			}
+		}
+	} else {
+		if err = r.parseFlags(); err != nil {
+			return err
+		}
+		if r.opts.FlagParsed() != nil {
+			// data could be changed by the callback, so fields are initialized again on the new copy
+			r.reset(size)
+			r.resolving = true
+			p, data, copied = r.target(rv, reload)
+			if err = p.init(); err != nil {
+				return err
+			}
+		}
+	}
+	if r.ext, err = r.loadExternal(); err != nil {
+		return err
+	}
+	extMapper := external.NewExternalConfigMapper(r.ext)
+	if err = r.setValidator(reflect.PtrTo(p.v.Type()), extMapper); err != nil {
+		return err
+	}
+	extData := data
+	if r.opts.StrictPriority() {
+		// external source is unmarshaled into the copy of data,
+		// values are applied to the fields that are defined by external source
+		shadow := reflect.New(p.v.Type())
+		shadow.Elem().Set(newShadow(p.v))
+		r.shadow = shadow.Elem()
+		extData = shadow.Interface()
+	}
+	if err = extMapper.Unmarshal(extData); err != nil {
+		return redactMapping(err, r.secretValues(extMapper.Data()))
+	}
+	if err = r.checkUnknownKeys(extMapper.UnknownKeys()); err != nil {
+		return err
+	}
+	p.ext = extMapper.Data()
+	if err = p.define(); err != nil {
+		return err
+	}
+	if _, _, ok := r.opts.EnvCheck(); ok {
+		if err = r.checkUnknownEnv(r.envNames()); err != nil {
+			return err
+		}
+	}
+	if copied {
+		commit(rv, p.v)
+	}
+	return nil
+}
+
+// target returns struct type of the value that configuration is resolved into and pointer to the value.
+// Configuration is resolved into the copy of data and committed only on success,
+// so data keeps its previous values if parsing fails. Returns true for the copy.
+// Reload resolves into the new value, so there is nothing to roll back
+func (r *resolver) target(rv reflect.Value, reload bool) (*structType, interface{}, bool) {
+	var data interface{}
+	copied := false
+	switch {
+	case reload:
+		rv.Set(newCopy(r.base))
+		data = rv.Addr().Interface()
+	case rv.CanAddr():
+		r.base = newCopy(rv)
+		data = newCopy(rv).Addr().Interface()
+		copied = true
+	default:
+		// data is passed by value
+		data = rv.Interface()
+	}
+	p, _ := newParentStructType(data, r)
+	return p, data, copied
+}
+
+// parseFlags parses command line with flags of the initialized fields
+func (r *resolver) parseFlags() error {
+	flagMu.Lock()
+	defer flagMu.Unlock()
+	if r.opts.Usage() != nil {
+		flag.Usage = r.opts.Usage()
+	}
+	flag.Parse()
+	if fp := r.opts.FlagParsed(); fp != nil {
+		return fp()
+	}
+	return nil
+}
+
+// setValidator checks keys of the structs defined by the external source
+// and enables schema validation of it
+func (r *resolver) setValidator(rt reflect.Type, extMapper *external.ExternalConfigMapper) error {
+	if r.ext == nil {
+		return nil
+	}
+	tagNames := r.ext.TagName()
+	s, ok := r.opts.SchemaValidation()
+	if ok && s == nil {
+		g := &schemaGenerator{
+			tagNames: tagNames,
+			visited:  make(map[reflect.Type]bool),
+		}
+		var err error
+		s, err = g.generate(rt.Elem())
+		if err != nil {
+			return err
+		}
+	}
+	extMapper.SetValidator(func(v interface{}) error {
+		if err := checkExternalKeys(rt, v, tagNames, "", make(map[reflect.Type]bool)); err != nil {
+			return err
+		}
+		if !ok {
+			return nil
+		}
+		err := s.Validate(v)
+		var vs jsonschema.Violations
+		if errors.As(err, &vs) {
+			return &ValidationError{Violations: vs}
+		}
+		return err
+	})
+	return nil
+}
+
+// secretValues returns values of the secret fields in the root external source ext
+func (r *resolver) secretValues(ext external.ExternalSource) []interface{} {
+	var values []interface{}
+	for _, cf := range r.fields {
+		if !cf.isSecret() {
+			continue
+		}
+		if v, ok := cf.externalValue(ext); ok {
+			values = append(values, v)
+		}
+	}
+	return values
+}
+
+// checkUnknownKeys reports keys of the external source that don't match any field
+func (r *resolver) checkUnknownKeys(keys []external.UnknownKey) error {
+	if !r.opts.CheckUnknownKeys() || len(keys) == 0 {
+		return nil
+	}
+	args := make([]option.UnknownKeyArg, len(keys))
+	for i, k := range keys {
+		args[i] = option.UnknownKeyArg{
+			Source:     option.ExternalSource,
+			Key:        k.FullPath(),
+			Suggestion: suggest(k.Key, k.Known),
+			Position:   k.Position,
+		}
+	}
+	sort.Slice(args, func(i, j int) bool { return args[i].Key < args[j].Key })
+	for _, arg := range args {
+		r.opts.OnUnknownKey(arg)
+	}
+	if !r.opts.StrictExternal() {
+		return nil
+	}
+	return unknownKeysError(args)
+}
+
+// envNames returns names of environment variables of the fields
+func (r *resolver) envNames() []string {
+	names := make([]string, 0, len(r.fields))
+	for _, cf := range r.fields {
+		names = append(names, cf.configuration.env.Name())
+	}
+	return names
+}
+
+// checkUnknownEnv reports environment variables with configured prefix
+// that don't match any of the names
+func (r *resolver) checkUnknownEnv(names []string) error {
+	prefix, strict, ok := r.opts.EnvCheck()
+	if !ok {
+		return nil
+	}
+	if r.usedEnv == nil {
+		r.usedEnv = make(map[string]struct{})
+	}
+	known := make([]string, 0, len(names))
+	for _, name := range names {
+		if name != tagIgnored && strings.HasPrefix(name, prefix) {
+			known = append(known, strings.TrimPrefix(name, prefix))
+			r.usedEnv[name] = struct{}{}
+		}
+	}
+	var args []option.UnknownKeyArg
+	for _, kv := range os.Environ() {
+		name, _, _ := strings.Cut(kv, "=")
+		if !strings.HasPrefix(name, prefix) {
+			continue
+		}
+		if _, ok := r.usedEnv[name]; ok {
+			continue
+		}
+		arg := option.UnknownKeyArg{
+			Source: option.EnvVariable,
+			Key:    name,
+		}
+		if s := suggest(strings.TrimPrefix(name, prefix), known); s != "" {
+			arg.Suggestion = prefix + s
+		}
+		args = append(args, arg)
+	}
+	if len(args) == 0 {
+		return nil
+	}
+	sort.Slice(args, func(i, j int) bool { return args[i].Key < args[j].Key })
+	for _, arg := range args {
+		r.opts.OnUnknownKey(arg)
+	}
+	if !strict {
+		return nil
+	}
+	return unknownKeysError(args)
+}
@@ -0,0 +1,85 @@
+package envconf
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/antonmashko/envconf/option"
+)
+
+// secretValue is implemented by Secret type. Fields of such types are always classified as secret
+type secretValue interface {
+	secretValue()
+}
+
+var secretValueType = reflect.TypeOf((*secretValue)(nil)).Elem()
+
+// Secret holds sensitive configuration value.
+// Value is redacted on formatting and marshaling, use Reveal for getting it.
+// Secret can be defined from any source as its underlying type
+type Secret[T any] struct {
+	v T
+}
+
+// NewSecret returns Secret with value v
+func NewSecret[T any](v T) Secret[T] {
+	return Secret[T]{v: v}
+}
+
+func (Secret[T]) secretValue() {}
+
+// Reveal returns underlying value
+func (s Secret[T]) Reveal() T {
+	return s.v
+}
+
+func (s Secret[T]) String() string {
+	return option.SecretMask
+}
+
+func (s Secret[T]) GoString() string {
+	return fmt.Sprintf("envconf.Secret[%s]{%s}", reflect.TypeOf((*T)(nil)).Elem(), option.SecretMask)
+}
+
+func (s Secret[T]) MarshalText() ([]byte, error) {
+	return []byte(option.SecretMask), nil
+}
+
+func (s Secret[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(option.SecretMask)
+}
+
+// UnmarshalText sets value from flag, environment variable or default value
+func (s *Secret[T]) UnmarshalText(text []byte) error {
+	rv := reflect.ValueOf(&s.v).Elem()
+	if implF := asImpl(rv); implF != nil {
+		return implF(text)
+	}
+	_, err := setFromString(rv, string(text))
+	return err
+}
+
+// UnmarshalJSON sets value from JSON external source
+func (s *Secret[T]) UnmarshalJSON(data []byte) error {
+	return json.Unmarshal(data, &s.v)
+}
+
+func isSecretType(rt reflect.Type) bool {
+	for rt.Kind() == reflect.Ptr {
+		rt = rt.Elem()
+	}
+	return rt.Implements(secretValueType)
+}
+
+// revealedType returns underlying type of Secret or nil for other types
+func revealedType(rt reflect.Type) reflect.Type {
+	if !rt.Implements(secretValueType) {
+		return nil
+	}
+	m, ok := rt.MethodByName("Reveal")
+	if !ok {
+		return nil
+	}
+	return m.Type.Out(0)
+}
@@ -0,0 +1,51 @@
+package envconf
+
+import (
+	"strings"
+)
+
+// suggest returns candidate closest to name by edit distance.
+// Returns empty string if nothing is similar enough
+func suggest(name string, candidates []string) string {
+	name = strings.ToLower(name)
+	var (
+		result string
+		best   = len(name)/2 + 1
+	)
+	for _, c := range candidates {
+		d := editDistance(name, strings.ToLower(c))
+		if d < best {
+			best = d
+			result = c
+		}
+	}
+	return result
+}
+
+// editDistance is a Levenshtein distance between a and b
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = prev[j-1] + cost
+			if prev[j]+1 < curr[j] {
+				curr[j] = prev[j] + 1
+			}
+			if curr[j-1]+1 < curr[j] {
+				curr[j] = curr[j-1] + 1
+			}
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(rb)]
+}
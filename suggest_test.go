@@ -0,0 +1,21 @@
+package envconf
+
+import "testing"
+
+func TestSuggest_Ok(t *testing.T) {
+	tests := []struct {
+		name       string
+		candidates []string
+		expected   string
+	}{
+		{"tiemout", []string{"Timeout", "Host"}, "Timeout"},
+		{"HSOT", []string{"PORT", "HOST"}, "HOST"},
+		{"abc", []string{"xyz"}, ""},
+		{"abc", nil, ""},
+	}
+	for _, tt := range tests {
+		if actual := suggest(tt.name, tt.candidates); actual != tt.expected {
+			t.Errorf("suggest(%s): expected=%q actual=%q", tt.name, tt.expected, actual)
+		}
+	}
+}
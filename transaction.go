@@ -0,0 +1,212 @@
+package envconf
+
+import (
+	"reflect"
+	"sync"
+)
+
+// newCopy returns addressable deep copy of rv
+func newCopy(rv reflect.Value) reflect.Value {
+	result := reflect.New(rv.Type()).Elem()
+	if !hasRefs(rv.Type()) {
+		result.Set(rv)
+		return result
+	}
+	result.Set(deepCopy(rv, make(map[copyKey]reflect.Value)))
+	return result
+}
+
+// refTypes caches result of hasRefs by reflect.Type
+var refTypes sync.Map
+
+// hasRefs reports whether values of rt reference memory that deepCopy copies:
+// pointers, interfaces, slices and maps. Other values are copied by assignment
+func hasRefs(rt reflect.Type) bool {
+	if v, ok := refTypes.Load(rt); ok {
+		return v.(bool)
+	}
+	result := typeHasRefs(rt)
+	refTypes.Store(rt, result)
+	return result
+}
+
+func typeHasRefs(rt reflect.Type) bool {
+	switch rt.Kind() {
+	case reflect.Struct:
+		for i := 0; i < rt.NumField(); i++ {
+			if typeHasRefs(rt.Field(i).Type) {
+				return true
+			}
+		}
+		return false
+	case reflect.Array:
+		return typeHasRefs(rt.Elem())
+	case reflect.Ptr, reflect.Interface, reflect.Slice, reflect.Map:
+		return true
+	default:
+		return false
+	}
+}
+
+type copyKey struct {
+	ptr uintptr
+	rt  reflect.Type
+}
+
+// deepCopy copies values of exported fields, pointers, slices and maps.
+// Unexported fields are copied by value
+func deepCopy(rv reflect.Value, visited map[copyKey]reflect.Value) reflect.Value {
+	rt := rv.Type()
+	switch rv.Kind() {
+	case reflect.Struct:
+		result := reflect.New(rt).Elem()
+		result.Set(rv)
+		for i := 0; i < rv.NumField(); i++ {
+			if f := result.Field(i); f.CanSet() {
+				f.Set(deepCopy(rv.Field(i), visited))
+			}
+		}
+		return result
+	case reflect.Ptr:
+		if rv.IsNil() {
+			return rv
+		}
+		key := copyKey{ptr: rv.Pointer(), rt: rt}
+		if v, ok := visited[key]; ok {
+			return v
+		}
+		result := reflect.New(rt.Elem())
+		visited[key] = result
+		result.Elem().Set(deepCopy(rv.Elem(), visited))
+		return result
+	case reflect.Interface:
+		if rv.IsNil() {
+			return rv
+		}
+		result := reflect.New(rt).Elem()
+		result.Set(deepCopy(rv.Elem(), visited))
+		return result
+	case reflect.Slice:
+		if rv.IsNil() {
+			return rv
+		}
+		result := reflect.MakeSlice(rt, rv.Len(), rv.Len())
+		for i := 0; i < rv.Len(); i++ {
+			result.Index(i).Set(deepCopy(rv.Index(i), visited))
+		}
+		return result
+	case reflect.Array:
+		result := reflect.New(rt).Elem()
+		for i := 0; i < rv.Len(); i++ {
+			result.Index(i).Set(deepCopy(rv.Index(i), visited))
+		}
+		return result
+	case reflect.Map:
+		if rv.IsNil() {
+			return rv
+		}
+		result := reflect.MakeMapWithSize(rt, rv.Len())
+		iter := rv.MapRange()
+		for iter.Next() {
+			result.SetMapIndex(iter.Key(), deepCopy(iter.Value(), visited))
+		}
+		return result
+	default:
+		return rv
+	}
+}
+
+// commit sets resolved values from src into dst.
+// Pointers of dst are kept, resolved values are written into them
+func commit(dst, src reflect.Value) {
+	if !dst.CanSet() {
+		return
+	}
+	if !hasRefs(dst.Type()) {
+		dst.Set(src)
+		return
+	}
+	switch dst.Kind() {
+	case reflect.Struct:
+		if !exportedOnly(dst.Type()) {
+			// unexported fields could be changed by custom unmarshaling
+			dst.Set(src)
+			return
+		}
+		for i := 0; i < dst.NumField(); i++ {
+			commit(dst.Field(i), src.Field(i))
+		}
+	case reflect.Array:
+		for i := 0; i < dst.Len(); i++ {
+			commit(dst.Index(i), src.Index(i))
+		}
+	case reflect.Slice, reflect.Map, reflect.Ptr, reflect.Interface:
+		if !commitShared(dst, src) {
+			dst.Set(src)
+		}
+	default:
+		dst.Set(src)
+	}
+}
+
+// commitShared writes src into the memory referenced by dst: pointee of the pointer,
+// backing array of the slice or the map. Values inside interfaces are handled the same way.
+// Returns false if dst doesn't reference memory that can hold src
+func commitShared(dst, src reflect.Value) bool {
+	if dst.Kind() == reflect.Interface {
+		if dst.IsNil() || src.IsNil() {
+			return false
+		}
+		dst, src = dst.Elem(), src.Elem()
+	}
+	if dst.Type() != src.Type() {
+		return false
+	}
+	switch dst.Kind() {
+	case reflect.Ptr:
+		if dst.IsNil() || src.IsNil() {
+			return false
+		}
+		if dst.Pointer() != src.Pointer() {
+			commit(dst.Elem(), src.Elem())
+		}
+		return true
+	case reflect.Slice:
+		if dst.IsNil() || src.IsNil() || dst.Len() != src.Len() {
+			return false
+		}
+		for i := 0; i < dst.Len(); i++ {
+			commit(dst.Index(i), src.Index(i))
+		}
+		return true
+	case reflect.Map:
+		if dst.IsNil() || src.IsNil() {
+			return false
+		}
+		iter := dst.MapRange()
+		for iter.Next() {
+			if !src.MapIndex(iter.Key()).IsValid() {
+				dst.SetMapIndex(iter.Key(), reflect.Value{})
+			}
+		}
+		iter = src.MapRange()
+		for iter.Next() {
+			// map values aren't addressable, value is replaced unless it references shared memory
+			if dv := dst.MapIndex(iter.Key()); !dv.IsValid() || !commitShared(dv, iter.Value()) {
+				dst.SetMapIndex(iter.Key(), iter.Value())
+			}
+		}
+		return true
+	default:
+		return false
+	}
+}
+
+func exportedOnly(rt reflect.Type) bool {
+	for i := 0; i < rt.NumField(); i++ {
+		if !rt.Field(i).IsExported() {
+			return false
+		}
+	}
+	return true
+}
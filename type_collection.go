@@ -65,14 +65,27 @@ func (c *collectionType) define() error {
 	case option.NoConfigValue:
 		v, err = c.cd.withoutValue()
 	case option.ExternalSource:
+		c.assignExternal(c.v)
 		v, err = c.cd.fromInterface(v, cs)
 	default:
 		// value specified for entire collection
 		str, ok := v.(string)
 		if !ok {
-			return ErrUnsupportedType
+			return &Error{
+				Inner:     c.conversionError(v, c.v.Type(), cs, ErrUnsupportedType),
+				FieldName: c.fullName(),
+				Message:   "v is not string",
+			}
 		}
 		v, err = c.cd.fromString(str, cs)
+		var e *Error
+		if err != nil && !errors.As(err, &e) {
+			err = &Error{
+				Inner:     c.conversionError(str, c.v.Type(), cs, err),
+				FieldName: c.fullName(),
+				Message:   "cannot set",
+			}
+		}
 	}
 
 	if err != nil {
@@ -111,7 +124,7 @@ func (s *sliceType) fromString(value string, cs option.ConfigSource) (interface{
 	for i := range sl {
 		rv := s.v.Index(i)
 		st := newDefinedConfigField(sl[i], cs, s,
-			reflect.StructField{Name: strconv.Itoa(i), Type: rv.Type()}, s.parser)
+			&reflect.StructField{Name: strconv.Itoa(i), Type: rv.Type()}, s.parser)
 		err := s.defineItem(rv, st)
 		if err != nil {
 			return nil, err
@@ -122,7 +135,7 @@ func (s *sliceType) fromString(value string, cs option.ConfigSource) (interface{
 
 func (s *sliceType) fromInterface(v interface{}, cs option.ConfigSource) (interface{}, error) {
 	if cs != option.ExternalSource {
-		return nil, ErrUnsupportedType
+		return nil, &Error{Inner: s.conversionError(v, s.v.Type(), cs, ErrUnsupportedType), FieldName: s.fullName()}
 	}
 	return s.rescan(cs)
 }
@@ -133,15 +146,15 @@ func (s *sliceType) withoutValue() (interface{}, error) {
 
 func (s *sliceType) rescan(cs option.ConfigSource) (interface{}, error) {
 	if !s.v.CanInterface() {
-		return nil, errors.New("reflect: cannot interface")
+		return nil, &Error{Inner: errors.New("reflect: cannot interface"), FieldName: s.fullName()}
 	}
 	for i := 0; i < s.v.Len(); i++ {
 		rv := s.v.Index(i)
 		if !rv.CanInterface() {
-			return nil, errors.New("reflect: cannot interface")
+			return nil, &Error{Inner: errors.New("reflect: cannot interface"), FieldName: s.fullName()}
 		}
 		st := newDefinedConfigField(rv.Interface(), cs, s,
-			reflect.StructField{Name: strconv.Itoa(i), Type: rv.Type()}, s.parser)
+			&reflect.StructField{Name: strconv.Itoa(i), Type: rv.Type()}, s.parser)
 		err := s.defineItem(rv, st)
 		if err != nil {
 			return nil, err
@@ -181,7 +194,7 @@ func (m *mapType) fromString(value string, cs option.ConfigSource) (interface{},
 			return nil, err
 		}
 		st := newDefinedConfigField(value, cs, m,
-			reflect.StructField{Name: fmt.Sprint(key), Type: rvalType}, m.parser)
+			&reflect.StructField{Name: fmt.Sprint(key), Type: rvalType}, m.parser)
 		rvvalue := reflect.New(rvalType).Elem()
 		err = m.defineItem(rvvalue, st)
 		if err != nil {
@@ -195,7 +208,7 @@ func (m *mapType) fromString(value string, cs option.ConfigSource) (interface{},
 
 func (m *mapType) fromInterface(v interface{}, cs option.ConfigSource) (interface{}, error) {
 	if cs != option.ExternalSource {
-		return nil, ErrUnsupportedType
+		return nil, &Error{Inner: m.conversionError(v, m.v.Type(), cs, ErrUnsupportedType), FieldName: m.fullName()}
 	}
 	return m.rescan(cs)
 }
@@ -206,20 +219,20 @@ func (m *mapType) withoutValue() (interface{}, error) {
 
 func (m *mapType) rescan(cs option.ConfigSource) (interface{}, error) {
 	if !m.v.CanInterface() {
-		return nil, errors.New("reflect: cannot interface")
+		return nil, &Error{Inner: errors.New("reflect: cannot interface"), FieldName: m.fullName()}
 	}
 	mp := m.v.MapRange()
 	for mp.Next() {
 		rkey := mp.Key()
 		rval := mp.Value()
 		if !rkey.CanInterface() {
-			return nil, errors.New("reflect: cannot interface map.Key")
+			return nil, &Error{Inner: errors.New("reflect: cannot interface map.Key"), FieldName: m.fullName()}
 		}
 		if !rval.CanInterface() {
-			return nil, errors.New("reflect: cannot interface map.Value")
+			return nil, &Error{Inner: errors.New("reflect: cannot interface map.Value"), FieldName: m.fullName()}
 		}
 		st := newDefinedConfigField(rval.Interface(), cs, m,
-			reflect.StructField{Name: fmt.Sprint(rkey.Interface()), Type: rval.Type()}, m.parser)
+			&reflect.StructField{Name: fmt.Sprint(rkey.Interface()), Type: rval.Type()}, m.parser)
 		err := m.defineItem(rval, st)
 		if err != nil {
 			return nil, err
@@ -11,8 +11,7 @@ import (
 
 type fieldType struct {
 	*configField
-	v  reflect.Value
-	sf reflect.StructField
+	v reflect.Value
 }
 
 func newFieldType(v reflect.Value, s *configField) *fieldType {
@@ -38,13 +37,14 @@ func (f *fieldType) define() error {
 
 	if cs == option.ExternalSource {
 		// field should be defined through External.Unmarshal func
+		f.assignExternal(f.v)
 		return f.set(v, cs)
 	}
 
 	str, ok := v.(string)
 	if !ok {
 		return &Error{
-			Inner:     ErrUnsupportedType,
+			Inner:     f.conversionError(v, f.v.Type(), cs, ErrUnsupportedType),
 			FieldName: f.fullName(),
 			Message:   "v is not string",
 		}
@@ -60,7 +60,7 @@ func (f *fieldType) define() error {
 	v, err = setFromString(f.v, str)
 	if err != nil {
 		return &Error{
-			Inner:     fmt.Errorf("type=%s source=%s. %w", f.sf.Type, cs, err),
+			Inner:     f.conversionError(str, f.v.Type(), cs, err),
 			FieldName: f.fullName(),
 			Message:   "cannot set",
 		}
@@ -80,13 +80,15 @@ func (f *interfaceFieldType) define() error {
 	}
 	if cs == option.ExternalSource {
 		// field should be defined through External.Unmarshal func
+		f.assignExternal(f.v)
 		return f.set(v, cs)
 	}
 	rv := reflect.ValueOf(v)
 	if !rv.Type().AssignableTo(f.v.Type()) {
 		return &Error{
+			Inner:     f.conversionError(v, f.v.Type(), cs, ErrUnsupportedType),
 			FieldName: f.fullName(),
-			Message:   fmt.Sprintf("unable to assign type %s to %s", rv.Type(), f.sf.Type),
+			Message:   fmt.Sprintf("unable to assign type %s to %s", rv.Type(), f.v.Type()),
 		}
 	}
 	f.v.Set(rv)
@@ -105,23 +107,27 @@ func (f *customSetFieldType) define() error {
 
 	if cs == option.ExternalSource {
 		// field should be defined through External.Unmarshal func
+		f.assignExternal(f.v)
 		return f.set(v, cs)
 	}
 
 	str, ok := v.(string)
 	if !ok {
 		return &Error{
-			Inner:     ErrUnsupportedType,
+			Inner:     f.conversionError(v, f.v.Type(), cs, ErrUnsupportedType),
 			FieldName: f.fullName(),
 			Message:   "v is not string",
 		}
 	}
 	implF := asImpl(f.v)
 	if implF == nil {
-		return errors.New("setter not found")
+		return &Error{
+			Inner:     f.conversionError(str, f.v.Type(), cs, errors.New("setter not found")),
+			FieldName: f.fullName(),
+		}
 	}
 	if err := implF([]byte(str)); err != nil {
-		return &Error{Inner: err, FieldName: f.fullName()}
+		return &Error{Inner: f.conversionError(str, f.v.Type(), cs, err), FieldName: f.fullName()}
 	}
 	return f.set(v, cs)
 }
@@ -25,6 +25,9 @@ func (i *interfaceType) externalSource() external.ExternalSource {
 }
 
 func (i *interfaceType) init() error {
+	if err := i.configField.init(i); err != nil {
+		return err
+	}
 	return i.f.init()
 }
 
@@ -54,6 +54,10 @@ func (p *ptrType) init() error {
 }
 
 func (p *ptrType) define() error {
+	if p.tmp != nil && p.tmp.Type() == p.v.Type() && p.tmp.Elem().Kind() != reflect.Ptr && !p.v.IsNil() {
+		// pointer is allocated by external source after init, its value is kept
+		p.tmp.Elem().Set(p.v.Elem())
+	}
 	err := p.f.define()
 	if err != nil {
 		return err
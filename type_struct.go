@@ -18,7 +18,7 @@ type structType struct {
 	fields   []field
 }
 
-func newParentStructType(data interface{}, parser *EnvConf) (*structType, error) {
+func newParentStructType(data interface{}, parser *resolver) (*structType, error) {
 	v := reflect.ValueOf(data)
 	for v.Kind() == reflect.Ptr {
 		// check on nil
@@ -32,18 +32,17 @@ func newParentStructType(data interface{}, parser *EnvConf) (*structType, error)
 		return nil, errors.New("invalid type")
 	}
 
-	s := newStructType(v, newConfigField(nil, reflect.StructField{}, parser))
+	s := newStructType(v, newConfigField(nil, &reflect.StructField{}, parser))
 	return s, nil
 }
 
 func newStructType(val reflect.Value, f *configField) *structType {
-	sname := f.Tag.Get("envconf")
+	sname := f.tag().sname
 	return &structType{
 		sname:       sname,
 		configField: f,
 		v:           val,
 		ext:         external.NilContainer{},
-		fields:      make([]field, val.NumField()),
 	}
 }
 
@@ -64,10 +63,14 @@ func (s *structType) externalSource() external.ExternalSource {
 
 func (s *structType) init() error {
 	s.fields = make([]field, s.v.NumField())
-	rt := s.v.Type()
+	plan := planOf(s.v.Type())
 	for i := 0; i < s.v.NumField(); i++ {
-		rfield := s.v.Field(i)
-		f := createFieldFromValue(rfield, newConfigField(s, rt.Field(i), s.parser))
+		cf := newConfigField(s, &plan.fields[i], s.parser)
+		cf.tags = plan.tags[i]
+		if err := cf.checkTags(); err != nil {
+			return err
+		}
+		f := createFieldFromValue(s.v.Field(i), cf)
 		if err := f.init(); err != nil {
 			return err
 		}
@@ -82,16 +85,23 @@ func (s *structType) define() error {
 		s.ext = external.AsExternalSource(s.Name, s.parentField.externalSource())
 	}
 	for _, f := range s.fields {
+		if cf := asConfigField(f); cf != nil && !cf.isSet() {
+			if err := cf.checkSources(); err != nil {
+				s.parser.fieldNotDefined(f, err)
+				return err
+			}
+		}
 		err := f.define()
 		if err != nil {
 			s.parser.fieldNotDefined(f, err)
-			if !errors.Is(err, ErrConfigurationNotFound) {
+			var re *RequiredError
+			if !errors.Is(err, ErrConfigurationNotFound) || errors.As(err, &re) {
 				return err
 			}
 			if rf, ok := f.(requiredField); ok && rf.IsRequired() {
 				return &Error{
 					Message:   "failed to define field",
-					Inner:     err,
+					Inner:     &RequiredError{FieldName: fullname(f, fieldNameDelim)},
 					FieldName: fullname(f, fieldNameDelim),
 				}
 			}
@@ -0,0 +1,70 @@
+package envconf
+
+import (
+	"reflect"
+	"sync"
+	"sync/atomic"
+)
+
+// publisher is implemented by Value. Parse resolves configuration into a draft
+// and publishes it on success. Reload resolves new value from the data captured by Parse and publishes it
+type publisher interface {
+	draft() interface{}
+	publish(interface{})
+}
+
+// Value holds configuration of type T that is safe for concurrent reading while it's replaced.
+// Pass *Value[T] into EnvConf.Parse instead of *T: each parsing and reload resolves fresh T
+// and publishes it atomically, so readers never see partly updated configuration
+type Value[T any] struct {
+	p    atomic.Pointer[T]
+	mu   sync.Mutex
+	subs []func(old, new T)
+}
+
+// NewValue returns Value with initial configuration v.
+// Parse resolves configuration on top of the current value
+func NewValue[T any](v T) *Value[T] {
+	val := &Value[T]{}
+	val.p.Store(&v)
+	return val
+}
+
+// Load returns current configuration
+func (v *Value[T]) Load() T {
+	if p := v.p.Load(); p != nil {
+		return *p
+	}
+	var zero T
+	return zero
+}
+
+// Subscribe adds callback that is called after new configuration is published
+func (v *Value[T]) Subscribe(fn func(old, new T)) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.subs = append(v.subs, fn)
+}
+
+// draft returns deep copy of the current value. Readers keep using the current value,
+// so neither it nor its nested pointers are changed by Parse
+func (v *Value[T]) draft() interface{} {
+	p := v.p.Load()
+	if p == nil {
+		return new(T)
+	}
+	return newCopy(reflect.ValueOf(p).Elem()).Addr().Interface()
+}
+
+func (v *Value[T]) publish(data interface{}) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	next := data.(*T)
+	var old T
+	if p := v.p.Swap(next); p != nil {
+		old = *p
+	}
+	for _, fn := range v.subs {
+		fn(old, *next)
+	}
+}
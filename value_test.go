@@ -0,0 +1,133 @@
+package envconf_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/antonmashko/envconf"
+	"github.com/antonmashko/envconf/external"
+	jsonconf "github.com/antonmashko/envconf/external/json"
+	"github.com/antonmashko/envconf/option"
+)
+
+type valueConfig struct {
+	HTTP *struct {
+		Port int `env:"TEST_VALUE_PORT"`
+	}
+	Hosts []string `env:"TEST_VALUE_HOSTS"`
+}
+
+func TestValue_ParseAndLoad_Ok(t *testing.T) {
+	v := &envconf.Value[valueConfig]{}
+	if cfg := v.Load(); cfg.HTTP != nil {
+		t.Fatalf("unexpected result: %#v", cfg)
+	}
+	var calls []int
+	v.Subscribe(func(old, new valueConfig) {
+		port := 0
+		if old.HTTP != nil {
+			port = old.HTTP.Port
+		}
+		calls = append(calls, port, new.HTTP.Port)
+	})
+	ec := envconf.New()
+	os.Setenv("TEST_VALUE_PORT", "80")
+	os.Setenv("TEST_VALUE_HOSTS", "a,b")
+	defer os.Unsetenv("TEST_VALUE_PORT")
+	defer os.Unsetenv("TEST_VALUE_HOSTS")
+	if err := ec.Parse(v); err != nil {
+		t.Fatal(err)
+	}
+	first := v.Load()
+	if first.HTTP == nil || first.HTTP.Port != 80 || len(first.Hosts) != 2 {
+		t.Fatalf("unexpected result: %#v", first)
+	}
+
+	os.Setenv("TEST_VALUE_PORT", "invalid")
+	if err := ec.Parse(v); err == nil {
+		t.Fatal("expected error but got nil")
+	}
+	os.Setenv("TEST_VALUE_PORT", "8080")
+	if err := ec.Parse(v); err != nil {
+		t.Fatal(err)
+	}
+	if v.Load().HTTP.Port != 8080 {
+		t.Fatalf("unexpected result: %#v", v.Load().HTTP)
+	}
+	if first.HTTP.Port != 80 {
+		t.Fatal("previous configuration is changed")
+	}
+	if len(calls) != 4 || calls[0] != 0 || calls[1] != 80 || calls[2] != 80 || calls[3] != 8080 {
+		t.Fatalf("unexpected subscriber calls: %v", calls)
+	}
+}
+
+func TestValue_ConcurrentLoad_Ok(t *testing.T) {
+	v := envconf.NewValue(valueConfig{Hosts: []string{"default"}})
+	ec := envconf.New()
+	done := make(chan struct{})
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-done:
+					return
+				default:
+				}
+				cfg := v.Load()
+				if cfg.HTTP != nil && len(cfg.Hosts) != cfg.HTTP.Port {
+					t.Errorf("partly updated configuration: %#v", cfg)
+					return
+				}
+			}
+		}()
+	}
+	hosts := ""
+	for i := 1; i <= 20; i++ {
+		hosts += "," + strconv.Itoa(i)
+		os.Setenv("TEST_VALUE_PORT", strconv.Itoa(i+1))
+		os.Setenv("TEST_VALUE_HOSTS", "h"+hosts)
+		if err := ec.Parse(v); err != nil {
+			t.Fatal(err)
+		}
+	}
+	close(done)
+	wg.Wait()
+	os.Unsetenv("TEST_VALUE_PORT")
+	os.Unsetenv("TEST_VALUE_HOSTS")
+}
+
+func TestValue_Watch_Ok(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	writeFile(t, path, `{"addr": "localhost", "port": 80}`)
+	v := &envconf.Value[watchConfig]{}
+	ec := envconf.New()
+	err := ec.Parse(v, option.WithFlagConfigFile("test-value-watch-config", path, "",
+		func(b []byte) (external.External, error) {
+			return jsonconf.Json(b), nil
+		}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	events, err := ec.Watch(ctx, envconf.WatchOptions{Interval: 5 * time.Millisecond})
+	if err != nil {
+		t.Fatal(err)
+	}
+	writeFile(t, path, `{"addr": "localhost", "port": 8080}`)
+	if ev := nextEvent(t, events); ev.Err != nil {
+		t.Fatal(ev.Err)
+	}
+	if cfg := v.Load(); cfg.Port != 8080 || cfg.Addr != "localhost" {
+		t.Fatalf("unexpected result: %#v", cfg)
+	}
+}
@@ -0,0 +1,14 @@
+// Command envconfvet checks envconf struct tags.
+//
+//	go install github.com/antonmashko/envconf/vet/cmd/envconfvet@latest
+//	go vet -vettool=$(which envconfvet) ./...
+package main
+
+import (
+	"github.com/antonmashko/envconf/vet"
+	"golang.org/x/tools/go/analysis/singlechecker"
+)
+
+func main() {
+	singlechecker.Main(vet.Analyzer)
+}
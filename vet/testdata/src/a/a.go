@@ -0,0 +1,86 @@
+package a
+
+import (
+	"context"
+	"time"
+
+	"github.com/antonmashko/envconf"
+	"github.com/antonmashko/envconf/option"
+)
+
+type DB struct {
+	Host string `env:"*"` // want `DB.Host: env name "DB_HOST" is already used by DBHost`
+	Port int    `env:"*" default:"5432"`
+}
+
+type Config struct {
+	DBHost   string         `env:"DB_HOST"`
+	Debug    bool           `required:"yes"`  // want `Debug: invalid required tag "yes": expected true or false`
+	Events   chan string    `flag:"*"`        // want `Events: field of unsupported type chan string can't be defined from flag or environment variable`
+	Timeout  time.Duration  `default:"10"`    // want `Timeout: invalid default value "10": time: missing unit in duration "10"`
+	Retries  []int          `default:"1,2,x"` // want `Retries: invalid default value "1,2,x": strconv.ParseInt: parsing "x": invalid syntax`
+	Limits   map[string]int `default:"a:1,b:2"`
+	Workers  int            `default:" 4 "`
+	Shards   int            `default:"4\t"` // want `Shards: invalid default value "4\\t": strconv.ParseInt: parsing "4\\t": invalid syntax`
+	Name     string         `flag:"name" env:"NAME" default:"svc" required:"true"`
+	Alias    string         `flag:"name"` // want `Alias: flag name "name" is already used by Name`
+	Internal chan int
+	DB       DB
+	Renamed  DB `envconf:"replica"`
+}
+
+func parse() {
+	var cfg Config
+	_ = envconf.Parse(&cfg)
+	_ = envconf.New().Parse(&cfg)
+}
+
+// Flags are checked by ParseContext
+type Flags struct {
+	Verbose bool `flag:"v"`
+	Quiet   bool `flag:"v"` // want `Quiet: flag name "v" is already used by Verbose`
+}
+
+// Secrets are checked by EnvConf.ParseContext
+type Secrets struct {
+	Token string               `secret:"yes"` // want `Token: invalid secret tag "yes": expected true or false`
+	TLS   struct{ Key string } `secret:"on"`  // want `TLS: invalid secret tag "on": expected true or false`
+}
+
+// Reloadable is checked by Begin of the generated code
+type Reloadable struct {
+	Level string `reload:"always"` // want `Level: invalid reload tag "always": expected true or false`
+}
+
+// Generated is checked by BeginContext of the generated code
+type Generated struct {
+	Port int `default:"http"` // want `Port: invalid default value "http": strconv.ParseInt: parsing "http": invalid syntax`
+}
+
+// Shared environment variable isn't reported with option.WithSharedEnv
+type Shared struct {
+	Primary DB
+	Host    string `env:"PRIMARY_HOST"` // want `Host: env name "PRIMARY_HOST" is already used by Primary.Host`
+}
+
+// GeneratedShared is checked by Begin of the generated code with option.WithSharedEnv
+type GeneratedShared struct {
+	Primary DB
+	Host    string `env:"PRIMARY_HOST"`
+}
+
+func parseOther(ctx context.Context) {
+	var flags Flags
+	_ = envconf.ParseContext(ctx, &flags)
+	var secrets Secrets
+	_ = envconf.New().ParseContext(ctx, &secrets, option.WithStrictEnv("APP_"))
+	var reloadable Reloadable
+	_ = envconf.New().Generated().Begin(&reloadable)
+	var generated Generated
+	_ = envconf.New().Generated().BeginContext(ctx, &generated)
+	var shared Shared
+	_ = envconf.Parse(&shared)
+	_ = envconf.Parse(&shared, option.WithSharedEnv())
+	var generatedShared GeneratedShared
+	_ = envconf.New().Generated(option.WithSharedEnv()).Begin(&generatedShared)
+}
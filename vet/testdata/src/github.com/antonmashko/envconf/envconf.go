@@ -0,0 +1,31 @@
+package envconf
+
+import (
+	"context"
+
+	"github.com/antonmashko/envconf/option"
+)
+
+type EnvConf struct{}
+
+func New() *EnvConf { return &EnvConf{} }
+
+func (e *EnvConf) Parse(data interface{}, opts ...option.ClientOption) error { return nil }
+
+func (e *EnvConf) ParseContext(ctx context.Context, data interface{}, opts ...option.ClientOption) error {
+	return nil
+}
+
+func (e *EnvConf) Generated(opts ...option.ClientOption) *Generated { return &Generated{} }
+
+func Parse(data interface{}, opts ...option.ClientOption) error { return nil }
+
+func ParseContext(ctx context.Context, data interface{}, opts ...option.ClientOption) error {
+	return nil
+}
+
+type Generated struct{}
+
+func (g *Generated) Begin(data interface{}) error { return nil }
+
+func (g *Generated) BeginContext(ctx context.Context, data interface{}) error { return nil }
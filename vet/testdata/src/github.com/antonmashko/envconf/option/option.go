@@ -0,0 +1,7 @@
+package option
+
+type ClientOption interface{}
+
+func WithSharedEnv() ClientOption { return nil }
+
+func WithStrictEnv(prefix string) ClientOption { return nil }
@@ -0,0 +1,407 @@
+// Package vet provides analyzer that checks envconf struct tags of the
+// structs passed to envconf.Parse, envconf.ParseContext, the same EnvConf methods
+// and to Generated.Begin of the code generated by envconfgen.
+// It applies the same rules as envconf applies during initialization,
+// so tag mistakes are reported at compile time.
+package vet
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+const (
+	envconfPkg = "github.com/antonmashko/envconf"
+	optionPkg  = envconfPkg + "/option"
+)
+
+const (
+	tagFlag     = "flag"
+	tagEnv      = "env"
+	tagDefault  = "default"
+	tagRequired = "required"
+	tagSecret   = "secret"
+	tagReload   = "reload"
+	tagEnvconf  = "envconf"
+	tagIgnored  = "-"
+	valDefault  = "*"
+)
+
+var Analyzer = &analysis.Analyzer{
+	Name:     "envconf",
+	Doc:      "check envconf struct tags of the structs passed to envconf.Parse and other entry points",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+func run(pass *analysis.Pass) (interface{}, error) {
+	insp := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	c := &checker{
+		pass:     pass,
+		reported: make(map[string]bool),
+	}
+	insp.Preorder([]ast.Node{(*ast.CallExpr)(nil)}, func(n ast.Node) {
+		call := n.(*ast.CallExpr)
+		idx, ok := dataArg(pass.TypesInfo, call)
+		if !ok || len(call.Args) <= idx {
+			return
+		}
+		st, ok := structOf(pass.TypesInfo.TypeOf(call.Args[idx]))
+		if !ok {
+			return
+		}
+		c.check(call, st, !hasSharedEnv(pass.TypesInfo, call))
+	})
+	return nil, nil
+}
+
+// entryPoints are functions and methods of envconf that resolve data, mapped to index of data argument
+var entryPoints = map[string]int{
+	"Parse":        0,
+	"ParseContext": 1,
+	"Begin":        0,
+	"BeginContext": 1,
+}
+
+// dataArg returns index of data argument if call is an entry point of envconf:
+// Parse or ParseContext function, the same methods of EnvConf or Begin and BeginContext of Generated
+func dataArg(info *types.Info, call *ast.CallExpr) (int, bool) {
+	fn, ok := funcOf(info, call)
+	if !ok || fn.Pkg().Path() != envconfPkg {
+		return 0, false
+	}
+	idx, ok := entryPoints[fn.Name()]
+	return idx, ok
+}
+
+func funcOf(info *types.Info, call *ast.CallExpr) (*types.Func, bool) {
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return nil, false
+	}
+	fn, ok := info.Uses[sel.Sel].(*types.Func)
+	if !ok || fn.Pkg() == nil {
+		return nil, false
+	}
+	return fn, true
+}
+
+// hasSharedEnv reports whether option.WithSharedEnv is passed to the call
+// or to EnvConf.Generated that returns receiver of Begin. Otherwise environment variable can't be shared by fields
+func hasSharedEnv(info *types.Info, call *ast.CallExpr) bool {
+	opts := call.Args
+	if sel, ok := call.Fun.(*ast.SelectorExpr); ok {
+		if gen, ok := sel.X.(*ast.CallExpr); ok {
+			if fn, ok := funcOf(info, gen); ok && fn.Pkg().Path() == envconfPkg && fn.Name() == "Generated" {
+				opts = append(opts[:len(opts):len(opts)], gen.Args...)
+			}
+		}
+	}
+	for _, opt := range opts {
+		call, ok := opt.(*ast.CallExpr)
+		if !ok {
+			continue
+		}
+		if fn, ok := funcOf(info, call); ok && fn.Pkg().Path() == optionPkg && fn.Name() == "WithSharedEnv" {
+			return true
+		}
+	}
+	return false
+}
+
+func structOf(t types.Type) (*types.Struct, bool) {
+	for {
+		p, ok := t.(*types.Pointer)
+		if !ok {
+			break
+		}
+		t = p.Elem()
+	}
+	if t == nil {
+		return nil, false
+	}
+	st, ok := t.Underlying().(*types.Struct)
+	return st, ok
+}
+
+type checker struct {
+	pass      *analysis.Pass
+	call      *ast.CallExpr
+	uniqueEnv bool
+	names     map[string]string
+	reported  map[string]bool
+}
+
+// field is a struct field with its path from the root struct
+type field struct {
+	v        *types.Var
+	tag      reflect.StructTag
+	path     []string // names used for generating configuration names
+	fullName string
+}
+
+func (c *checker) check(call *ast.CallExpr, st *types.Struct, uniqueEnv bool) {
+	c.call = call
+	c.uniqueEnv = uniqueEnv
+	c.names = make(map[string]string)
+	c.walk(st, nil, "", make(map[*types.Struct]bool))
+}
+
+func (c *checker) walk(st *types.Struct, path []string, fullName string, visited map[*types.Struct]bool) {
+	if visited[st] {
+		return
+	}
+	visited[st] = true
+	defer delete(visited, st)
+	for i := 0; i < st.NumFields(); i++ {
+		v := st.Field(i)
+		if !v.Exported() {
+			continue
+		}
+		f := field{
+			v:        v,
+			tag:      reflect.StructTag(st.Tag(i)),
+			path:     append(path[:len(path):len(path)], v.Name()),
+			fullName: v.Name(),
+		}
+		if fullName != "" {
+			f.fullName = fullName + "." + v.Name()
+		}
+		// secret and reload tags of structs are inherited by nested fields, so they're checked for any field
+		c.checkBool(f, tagSecret)
+		c.checkBool(f, tagReload)
+		t := v.Type()
+		for {
+			p, ok := t.(*types.Pointer)
+			if !ok {
+				break
+			}
+			t = p.Elem()
+		}
+		if nst, ok := t.Underlying().(*types.Struct); ok && !isTextUnmarshaler(t) {
+			if name := f.tag.Get(tagEnvconf); name != "" {
+				f.path[len(f.path)-1] = name
+			}
+			c.walk(nst, f.path, f.fullName, visited)
+			continue
+		}
+		c.checkField(f, t)
+	}
+}
+
+func (c *checker) checkField(f field, t types.Type) {
+	c.checkBool(f, tagRequired)
+
+	flagName := configName(f, tagFlag, "-", strings.ToLower)
+	envName := configName(f, tagEnv, "_", strings.ToUpper)
+	if !supported(t) {
+		if flagName != "" || envName != "" {
+			c.reportf(f, "field of unsupported type %s can't be defined from flag or environment variable", t)
+		}
+		return
+	}
+	c.register(f, "flag", flagName)
+	if c.uniqueEnv {
+		c.register(f, "env", envName)
+	}
+
+	if dv, ok := f.tag.Lookup(tagDefault); ok {
+		if err := checkDefault(t, dv); err != nil {
+			c.reportf(f, "invalid default value %q: %s", dv, err)
+		}
+	}
+}
+
+// checkBool reports value of the tag that isn't a bool
+func (c *checker) checkBool(f field, tag string) {
+	if str, ok := f.tag.Lookup(tag); ok {
+		if _, err := strconv.ParseBool(str); err != nil {
+			c.reportf(f, "invalid %s tag %q: expected true or false", tag, str)
+		}
+	}
+}
+
+func (c *checker) register(f field, kind, name string) {
+	if name == "" {
+		return
+	}
+	key := kind + ":" + name
+	if other, ok := c.names[key]; ok {
+		c.reportf(f, "%s name %q is already used by %s", kind, name, other)
+		return
+	}
+	c.names[key] = f.fullName
+}
+
+// reportf reports problem at the field position if it's declared in the analyzed package,
+// otherwise at the position of Parse call
+func (c *checker) reportf(f field, format string, args ...interface{}) {
+	pos := f.v.Pos()
+	if !c.inPass(pos) {
+		pos = c.call.Pos()
+	}
+	msg := f.fullName + ": " + fmt.Sprintf(format, args...)
+	key := fmt.Sprint(pos, msg)
+	if c.reported[key] {
+		return
+	}
+	c.reported[key] = true
+	c.pass.Reportf(pos, "%s", msg)
+}
+
+func (c *checker) inPass(pos token.Pos) bool {
+	for _, f := range c.pass.Files {
+		if f.Pos() <= pos && pos <= f.End() {
+			return true
+		}
+	}
+	return false
+}
+
+// configName returns flag or env name of the field. `*` generates name from the field path
+func configName(f field, tag, delim string, conv func(string) string) string {
+	name, ok := f.tag.Lookup(tag)
+	if !ok || name == "" || name == tagIgnored {
+		return ""
+	}
+	if name == valDefault {
+		return conv(strings.Join(f.path, delim))
+	}
+	return name
+}
+
+// supported reports whether envconf is able to define value of the type
+func supported(t types.Type) bool {
+	switch ut := t.Underlying().(type) {
+	case *types.Chan, *types.Signature:
+		return false
+	case *types.Basic:
+		return ut.Kind() != types.UnsafePointer && ut.Kind() != types.Uintptr
+	default:
+		return true
+	}
+}
+
+func isTextUnmarshaler(t types.Type) bool {
+	for _, tt := range []types.Type{t, types.NewPointer(t)} {
+		ms := types.NewMethodSet(tt)
+		for i := 0; i < ms.Len(); i++ {
+			name := ms.At(i).Obj().Name()
+			if name == "UnmarshalText" || name == "UnmarshalBinary" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func isDuration(t types.Type) bool {
+	n, ok := t.(*types.Named)
+	if !ok {
+		return false
+	}
+	obj := n.Obj()
+	return obj.Pkg() != nil && obj.Pkg().Path() == "time" && obj.Name() == "Duration"
+}
+
+// checkDefault verifies that value can be converted into the type.
+// Values of the types with custom unmarshaling are not checked
+func checkDefault(t types.Type, value string) error {
+	for {
+		p, ok := t.(*types.Pointer)
+		if !ok {
+			break
+		}
+		t = p.Elem()
+	}
+	if isTextUnmarshaler(t) {
+		return nil
+	}
+	if isDuration(t) {
+		_, err := time.ParseDuration(strings.Trim(value, " "))
+		return err
+	}
+	switch ut := t.Underlying().(type) {
+	case *types.Slice:
+		if b, ok := ut.Elem().Underlying().(*types.Basic); ok && b.Kind() == types.Uint8 {
+			return nil
+		}
+		for _, item := range strings.Split(value, ",") {
+			if err := checkDefault(ut.Elem(), item); err != nil {
+				return err
+			}
+		}
+		return nil
+	case *types.Array:
+		items := strings.Split(value, ",")
+		if int64(len(items)) > ut.Len() {
+			return fmt.Errorf("elements in value more than len of array")
+		}
+		for _, item := range items {
+			if err := checkDefault(ut.Elem(), item); err != nil {
+				return err
+			}
+		}
+		return nil
+	case *types.Map:
+		for _, item := range strings.Split(value, ",") {
+			key, val, _ := strings.Cut(item, ":")
+			if err := checkDefault(ut.Key(), key); err != nil {
+				return err
+			}
+			if err := checkDefault(ut.Elem(), val); err != nil {
+				return err
+			}
+		}
+		return nil
+	case *types.Basic:
+		return checkBasic(ut, value)
+	default:
+		return nil
+	}
+}
+
+func checkBasic(b *types.Basic, value string) error {
+	// values are trimmed in the same way as parsing does, only spaces are removed
+	value = strings.Trim(value, " ")
+	var err error
+	switch b.Kind() {
+	case types.Bool:
+		_, err = strconv.ParseBool(value)
+	case types.Int, types.Int64:
+		_, err = strconv.ParseInt(value, 0, 64)
+	case types.Int8:
+		_, err = strconv.ParseInt(value, 0, 8)
+	case types.Int16:
+		_, err = strconv.ParseInt(value, 0, 16)
+	case types.Int32:
+		_, err = strconv.ParseInt(value, 0, 32)
+	case types.Uint, types.Uint64:
+		_, err = strconv.ParseUint(value, 0, 64)
+	case types.Uint8:
+		_, err = strconv.ParseUint(value, 0, 8)
+	case types.Uint16:
+		_, err = strconv.ParseUint(value, 0, 16)
+	case types.Uint32:
+		_, err = strconv.ParseUint(value, 0, 32)
+	case types.Float32:
+		_, err = strconv.ParseFloat(value, 32)
+	case types.Float64:
+		_, err = strconv.ParseFloat(value, 64)
+	case types.Complex64:
+		_, err = strconv.ParseComplex(value, 64)
+	case types.Complex128:
+		_, err = strconv.ParseComplex(value, 128)
+	}
+	return err
+}
@@ -0,0 +1,12 @@
+package vet_test
+
+import (
+	"testing"
+
+	"github.com/antonmashko/envconf/vet"
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestAnalyzer(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), vet.Analyzer, "a")
+}
@@ -0,0 +1,193 @@
+package envconf
+
+import (
+	"context"
+	"errors"
+	"os"
+	"reflect"
+	"time"
+
+	"github.com/antonmashko/envconf/external"
+	"github.com/antonmashko/envconf/option"
+)
+
+const (
+	defaultWatchInterval = time.Second
+	defaultWatchDebounce = 100 * time.Millisecond
+)
+
+// WatchOptions configures watching of the configuration file and providers
+type WatchOptions struct {
+	// Interval between checks of the file modification and polls of providers. Default: 1s
+	Interval time.Duration
+	// Debounce is a time the file should stay unchanged before configuration is reloaded. Default: 100ms
+	Debounce time.Duration
+	// OnReload is called after every reload attempt
+	OnReload func(ReloadEvent)
+}
+
+// ReloadEvent is a result of configuration reload
+type ReloadEvent struct {
+	// Config is a new configuration of the same type as data passed to EnvConf.Parse.
+	// Nil if reload failed
+	Config interface{}
+	// Changes of the fields compared to the previous resolution
+	Changes []option.FieldChange
+	// Err is a reason of failed reload. Previous configuration stays in effect
+	Err error
+}
+
+// ErrNoConfigFile returns by Watch when external source isn't read from file and no provider implements external.Watcher
+var ErrNoConfigFile = errors.New("envconf: external source doesn't have configuration file")
+
+type fileState struct {
+	modTime time.Time
+	size    int64
+	exists  bool
+}
+
+func statFile(path string) fileState {
+	fi, err := os.Stat(path)
+	if err != nil {
+		return fileState{}
+	}
+	return fileState{modTime: fi.ModTime(), size: fi.Size(), exists: true}
+}
+
+// Watch polls configuration file of the external source (see option.WithFlagConfigFile)
+// and providers that implement external.Watcher, and resolves configuration again after the data changes.
+// Errors of the watchers are delivered as events with ReloadEvent.Err.
+// Watch should be called after successful EnvConf.Parse. Data passed to Parse isn't changed,
+// each reload resolves new configuration that is delivered by the returned channel and wo.OnReload callback.
+// Values of flags and environment variables are kept as captured by Parse.
+// Unlike reload on signal (see option.WithReloadSignal), changes of the fields without reload tag are accepted.
+// Channel is closed when ctx is done. If receiver doesn't keep up, only the latest event is kept in the channel
+func (e *EnvConf) Watch(ctx context.Context, wo WatchOptions) (<-chan ReloadEvent, error) {
+	e.mu.Lock()
+	last := e.last
+	e.mu.Unlock()
+	if last == nil {
+		return nil, errors.New("envconf: Watch is called before Parse")
+	}
+	var watchers []external.Watcher
+	for _, p := range last.opts.Providers() {
+		if pw, ok := p.(external.Watcher); ok {
+			watchers = append(watchers, pw)
+		}
+	}
+	cf, ok := last.opts.External().(option.ConfigFile)
+	if !ok && len(watchers) == 0 {
+		return nil, ErrNoConfigFile
+	}
+	if wo.Interval <= 0 {
+		wo.Interval = defaultWatchInterval
+	}
+	if wo.Debounce <= 0 {
+		wo.Debounce = defaultWatchDebounce
+	}
+	w := &watcher{
+		ec:       e,
+		wo:       wo,
+		watchers: watchers,
+		events:   make(chan ReloadEvent, 1),
+	}
+	if cf != nil {
+		w.path = cf.Path()
+		w.state = statFile(w.path)
+	}
+	go w.run(ctx)
+	return w.events, nil
+}
+
+type watcher struct {
+	ec *EnvConf
+	// path is empty if external source isn't read from file
+	path     string
+	wo       WatchOptions
+	state    fileState
+	watchers []external.Watcher
+	events   chan ReloadEvent
+}
+
+func (w *watcher) run(ctx context.Context) {
+	defer close(w.events)
+	updates := make(chan error)
+	for _, pw := range w.watchers {
+		go w.watch(ctx, pw, updates)
+	}
+	ticker := time.NewTicker(w.wo.Interval)
+	defer ticker.Stop()
+	var changed time.Time
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case err := <-updates:
+			w.reload(ctx, err)
+		case now := <-ticker.C:
+			if w.path == "" {
+				continue
+			}
+			if st := statFile(w.path); st != w.state {
+				w.state = st
+				changed = now
+				continue
+			}
+			if changed.IsZero() || now.Sub(changed) < w.wo.Debounce {
+				continue
+			}
+			changed = time.Time{}
+			w.reload(ctx, nil)
+		}
+	}
+}
+
+// watch checks pw every interval and sends nil to updates when its data changed or error of the check.
+// Each watcher runs in its own goroutine, so blocking checks, e.g. queries of Consul,
+// don't delay checks of the file and other watchers
+func (w *watcher) watch(ctx context.Context, pw external.Watcher, updates chan<- error) {
+	ticker := time.NewTicker(w.wo.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		changed, err := pw.Changed(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+		if err != nil {
+			err = &LoadError{Source: pw.Name(), Err: err}
+		} else if !changed {
+			continue
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case updates <- err:
+		}
+	}
+}
+
+// reload resolves configuration again and delivers the result. If err isn't nil, it's delivered without reload
+func (w *watcher) reload(ctx context.Context, err error) {
+	ev := ReloadEvent{Err: err}
+	if err == nil {
+		var rv reflect.Value
+		rv, ev.Changes, ev.Err = w.ec.reload(ctx, false)
+		if ev.Err == nil {
+			ev.Config = rv.Interface()
+		}
+	}
+	if w.wo.OnReload != nil {
+		w.wo.OnReload(ev)
+	}
+	// replacing unread event
+	select {
+	case <-w.events:
+	default:
+	}
+	w.events <- ev
+}
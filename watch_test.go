@@ -0,0 +1,278 @@
+package envconf_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/antonmashko/envconf"
+	"github.com/antonmashko/envconf/external"
+	httpconf "github.com/antonmashko/envconf/external/http"
+	jsonconf "github.com/antonmashko/envconf/external/json"
+	"github.com/antonmashko/envconf/option"
+)
+
+type watchConfig struct {
+	Addr  string `json:"addr"`
+	Port  int    `json:"port"`
+	Level string `json:"level" env:"TEST_WATCH_LEVEL"`
+}
+
+// writeFile replaces the file by renaming, so readers don't see partially written content
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	// modification time resolution of some file systems is low
+	future := time.Now().Add(time.Duration(len(content)) * time.Second)
+	if err := os.Chtimes(tmp, future, future); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func nextEvent(t *testing.T, events <-chan envconf.ReloadEvent) envconf.ReloadEvent {
+	t.Helper()
+	select {
+	case ev := <-events:
+		return ev
+	case <-time.After(5 * time.Second):
+		t.Fatal("reload event timeout")
+	}
+	return envconf.ReloadEvent{}
+}
+
+func TestWatch_Reload_Ok(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	writeFile(t, path, `{"addr": "localhost", "port": 80}`)
+	os.Setenv("TEST_WATCH_LEVEL", "debug")
+	defer os.Unsetenv("TEST_WATCH_LEVEL")
+
+	var cfg watchConfig
+	ec := envconf.New()
+	err := ec.Parse(&cfg, option.WithFlagConfigFile("test-watch-reload-config", path, "",
+		func(b []byte) (external.External, error) {
+			return jsonconf.Json(b), nil
+		}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Port != 80 || cfg.Level != "debug" {
+		t.Fatalf("unexpected result: %#v", cfg)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var callbacks int
+	events, err := ec.Watch(ctx, envconf.WatchOptions{
+		Interval: 5 * time.Millisecond,
+		Debounce: 20 * time.Millisecond,
+		OnReload: func(envconf.ReloadEvent) { callbacks++ },
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// environment variables are captured by Parse
+	os.Setenv("TEST_WATCH_LEVEL", "info")
+	writeFile(t, path, `{"addr": "0.0.0.0", "port": 8080}`)
+	ev := nextEvent(t, events)
+	if ev.Err != nil {
+		t.Fatal(ev.Err)
+	}
+	next, ok := ev.Config.(*watchConfig)
+	if !ok {
+		t.Fatalf("unexpected config type: %T", ev.Config)
+	}
+	if next.Addr != "0.0.0.0" || next.Port != 8080 || next.Level != "debug" {
+		t.Fatalf("unexpected result: %#v", next)
+	}
+	if cfg.Port != 80 {
+		t.Fatalf("parsed data is changed: %#v", cfg)
+	}
+
+	writeFile(t, path, `{"addr": "0.0.0.0", "port": "invalid"}`)
+	ev = nextEvent(t, events)
+	if ev.Err == nil || ev.Config != nil {
+		t.Fatalf("expected error but got: %#v", ev)
+	}
+	cancel()
+	if _, ok := <-events; ok {
+		t.Fatal("channel isn't closed")
+	}
+	if callbacks != 2 {
+		t.Fatalf("unexpected number of callbacks: %d", callbacks)
+	}
+}
+
+func TestWatch_WithoutConfigFile_Err(t *testing.T) {
+	var cfg watchConfig
+	ec := envconf.New()
+	if _, err := ec.Watch(context.Background(), envconf.WatchOptions{}); err == nil {
+		t.Fatal("expected error but got nil")
+	}
+	if err := ec.Parse(&cfg, option.WithExternal(jsonconf.Json(`{}`))); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ec.Watch(context.Background(), envconf.WatchOptions{}); !errors.Is(err, envconf.ErrNoConfigFile) {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestWatch_Provider_Ok(t *testing.T) {
+	var data atomic.Value
+	data.Store(`{"addr": "localhost", "port": 80}`)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b := data.Load().(string)
+		etag := `"` + b + `"`
+		if r.Header.Get("If-None-Match") == etag {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", etag)
+		w.Write([]byte(b))
+	}))
+	defer srv.Close()
+
+	var cfg watchConfig
+	ec := envconf.New()
+	err := ec.Parse(&cfg, option.WithProvider(httpconf.New(srv.URL, func(b []byte) (external.External, error) {
+		return jsonconf.Json(b), nil
+	})))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fr, _ := ec.Report().Field("Port"); cfg.Port != 80 || fr.Position.String() != srv.URL+":1:31" {
+		t.Fatalf("unexpected result: %#v %s", cfg, fr.Position)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	events, err := ec.Watch(ctx, envconf.WatchOptions{Interval: 5 * time.Millisecond})
+	if err != nil {
+		t.Fatal(err)
+	}
+	data.Store(`{"addr": "localhost", "port": 8080}`)
+	ev := nextEvent(t, events)
+	if ev.Err != nil {
+		t.Fatal(ev.Err)
+	}
+	if next := ev.Config.(*watchConfig); next.Port != 8080 {
+		t.Fatalf("unexpected result: %#v", next)
+	}
+
+	srv.Close()
+	var le *envconf.LoadError
+	if ev = nextEvent(t, events); !errors.As(ev.Err, &le) || le.Source != srv.URL {
+		t.Fatalf("expected LoadError but got %v", ev.Err)
+	}
+}
+
+// blockingWatcher is a Watcher that reports changes only when ctx is done, as a long blocking query
+type blockingWatcher struct{}
+
+func (blockingWatcher) Name() string {
+	return "blocking"
+}
+
+func (blockingWatcher) Load(context.Context) (external.External, error) {
+	return jsonconf.Json(`{"level": "info"}`), nil
+}
+
+func (blockingWatcher) Changed(ctx context.Context) (bool, error) {
+	<-ctx.Done()
+	return false, ctx.Err()
+}
+
+func TestWatch_BlockingWatcher_Ok(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	writeFile(t, path, `{"port": 80}`)
+	var cfg watchConfig
+	ec := envconf.New()
+	err := ec.Parse(&cfg,
+		option.WithFlagConfigFile("test-watch-blocking-config", path, "",
+			func(b []byte) (external.External, error) {
+				return jsonconf.Json(b), nil
+			}),
+		option.WithProvider(blockingWatcher{}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	events, err := ec.Watch(ctx, envconf.WatchOptions{
+		Interval: 5 * time.Millisecond,
+		Debounce: 20 * time.Millisecond,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// blocked watcher doesn't delay checks of the file
+	writeFile(t, path, `{"port": 8080}`)
+	ev := nextEvent(t, events)
+	if ev.Err != nil {
+		t.Fatal(ev.Err)
+	}
+	if next := ev.Config.(*watchConfig); next.Port != 8080 || next.Level != "info" {
+		t.Fatalf("unexpected result: %#v", next)
+	}
+	cancel()
+	for range events {
+	}
+}
+
+func TestWatch_ConcurrentParse_Ok(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	writeFile(t, path, `{"addr": "localhost", "port": 80}`)
+	opt := option.WithFlagConfigFile("test-watch-concurrent-config", path, "",
+		func(b []byte) (external.External, error) {
+			return jsonconf.Json(b), nil
+		})
+	ec := envconf.New()
+	var cfg watchConfig
+	if err := ec.Parse(&cfg, opt); err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	events, err := ec.Watch(ctx, envconf.WatchOptions{Interval: time.Millisecond, Debounce: time.Millisecond})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// configuration file is read again by Parse and reload of the watcher
+	stop, done := make(chan struct{}), make(chan struct{})
+	go func() {
+		defer close(done)
+		for {
+			select {
+			case <-stop:
+				return
+			default:
+			}
+			var cfg watchConfig
+			if err := ec.Parse(&cfg, opt); err != nil {
+				t.Error(err)
+				return
+			}
+		}
+	}()
+	for i := 0; i < 5; i++ {
+		writeFile(t, path, fmt.Sprintf(`{"addr": "0.0.0.0", "port": %d}`, 8080+i))
+		if ev := nextEvent(t, events); ev.Err != nil {
+			t.Fatal(ev.Err)
+		}
+	}
+	close(stop)
+	<-done
+}